@@ -0,0 +1,1208 @@
+// Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// You can obtain one at https://github.com/houseme/icp-filing.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: filing/v1/filing.proto
+
+package filingv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ServiceType is the kind of the filed service, the values are the upstream ones.
+type ServiceType int32
+
+const (
+	ServiceType_SERVICE_TYPE_UNSPECIFIED  ServiceType = 0
+	ServiceType_SERVICE_TYPE_WEBSITE      ServiceType = 1
+	ServiceType_SERVICE_TYPE_APP          ServiceType = 6
+	ServiceType_SERVICE_TYPE_MINI_PROGRAM ServiceType = 7
+	ServiceType_SERVICE_TYPE_QUICK_APP    ServiceType = 8
+)
+
+// Enum value maps for ServiceType.
+var (
+	ServiceType_name = map[int32]string{
+		0: "SERVICE_TYPE_UNSPECIFIED",
+		1: "SERVICE_TYPE_WEBSITE",
+		6: "SERVICE_TYPE_APP",
+		7: "SERVICE_TYPE_MINI_PROGRAM",
+		8: "SERVICE_TYPE_QUICK_APP",
+	}
+	ServiceType_value = map[string]int32{
+		"SERVICE_TYPE_UNSPECIFIED":  0,
+		"SERVICE_TYPE_WEBSITE":      1,
+		"SERVICE_TYPE_APP":          6,
+		"SERVICE_TYPE_MINI_PROGRAM": 7,
+		"SERVICE_TYPE_QUICK_APP":    8,
+	}
+)
+
+func (x ServiceType) Enum() *ServiceType {
+	p := new(ServiceType)
+	*p = x
+	return p
+}
+
+func (x ServiceType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServiceType) Descriptor() protoreflect.EnumDescriptor {
+	return file_filing_v1_filing_proto_enumTypes[0].Descriptor()
+}
+
+func (ServiceType) Type() protoreflect.EnumType {
+	return &file_filing_v1_filing_proto_enumTypes[0]
+}
+
+func (x ServiceType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServiceType.Descriptor instead.
+func (ServiceType) EnumDescriptor() ([]byte, []int) {
+	return file_filing_v1_filing_proto_rawDescGZIP(), []int{0}
+}
+
+type LookupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Query:
+	//	*LookupRequest_Domain
+	//	*LookupRequest_Unit
+	Query isLookupRequest_Query `protobuf_oneof:"query"`
+	// service type, website when unspecified
+	ServiceType ServiceType `protobuf:"varint,3,opt,name=service_type,json=serviceType,proto3,enum=filing.v1.ServiceType" json:"service_type,omitempty"`
+	// page number, 1 when unset
+	Page int32 `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	// page size, 10 when unset
+	Size int32 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *LookupRequest) Reset() {
+	*x = LookupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filing_v1_filing_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LookupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupRequest) ProtoMessage() {}
+
+func (x *LookupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_filing_v1_filing_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupRequest.ProtoReflect.Descriptor instead.
+func (*LookupRequest) Descriptor() ([]byte, []int) {
+	return file_filing_v1_filing_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *LookupRequest) GetQuery() isLookupRequest_Query {
+	if m != nil {
+		return m.Query
+	}
+	return nil
+}
+
+func (x *LookupRequest) GetDomain() string {
+	if x, ok := x.GetQuery().(*LookupRequest_Domain); ok {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *LookupRequest) GetUnit() string {
+	if x, ok := x.GetQuery().(*LookupRequest_Unit); ok {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *LookupRequest) GetServiceType() ServiceType {
+	if x != nil {
+		return x.ServiceType
+	}
+	return ServiceType_SERVICE_TYPE_UNSPECIFIED
+}
+
+func (x *LookupRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *LookupRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type isLookupRequest_Query interface {
+	isLookupRequest_Query()
+}
+
+type LookupRequest_Domain struct {
+	// domain or URL, only the records of its registrable domain are returned
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3,oneof"`
+}
+
+type LookupRequest_Unit struct {
+	// unit name, such as a company name
+	Unit string `protobuf:"bytes,2,opt,name=unit,proto3,oneof"`
+}
+
+func (*LookupRequest_Domain) isLookupRequest_Query() {}
+
+func (*LookupRequest_Unit) isLookupRequest_Query() {}
+
+type LookupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the domain or unit of the request
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// the registrable domain of a domain request
+	Domain string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	// whether a matching record is found
+	Filed bool `protobuf:"varint,3,opt,name=filed,proto3" json:"filed,omitempty"`
+	// the upstream page, its list only holds the matching records
+	Params *QueryParams `protobuf:"bytes,4,opt,name=params,proto3" json:"params,omitempty"`
+}
+
+func (x *LookupResponse) Reset() {
+	*x = LookupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filing_v1_filing_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LookupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupResponse) ProtoMessage() {}
+
+func (x *LookupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_filing_v1_filing_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupResponse.ProtoReflect.Descriptor instead.
+func (*LookupResponse) Descriptor() ([]byte, []int) {
+	return file_filing_v1_filing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LookupResponse) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *LookupResponse) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *LookupResponse) GetFiled() bool {
+	if x != nil {
+		return x.Filed
+	}
+	return false
+}
+
+func (x *LookupResponse) GetParams() *QueryParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type BatchLookupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*LookupRequest `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *BatchLookupRequest) Reset() {
+	*x = BatchLookupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filing_v1_filing_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchLookupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchLookupRequest) ProtoMessage() {}
+
+func (x *BatchLookupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_filing_v1_filing_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchLookupRequest.ProtoReflect.Descriptor instead.
+func (*BatchLookupRequest) Descriptor() ([]byte, []int) {
+	return file_filing_v1_filing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatchLookupRequest) GetItems() []*LookupRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type BatchLookupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// index of the item in the request
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// Types that are assignable to Result:
+	//	*BatchLookupResponse_Response
+	//	*BatchLookupResponse_Error
+	Result isBatchLookupResponse_Result `protobuf_oneof:"result"`
+}
+
+func (x *BatchLookupResponse) Reset() {
+	*x = BatchLookupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filing_v1_filing_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchLookupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchLookupResponse) ProtoMessage() {}
+
+func (x *BatchLookupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_filing_v1_filing_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchLookupResponse.ProtoReflect.Descriptor instead.
+func (*BatchLookupResponse) Descriptor() ([]byte, []int) {
+	return file_filing_v1_filing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchLookupResponse) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (m *BatchLookupResponse) GetResult() isBatchLookupResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *BatchLookupResponse) GetResponse() *LookupResponse {
+	if x, ok := x.GetResult().(*BatchLookupResponse_Response); ok {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *BatchLookupResponse) GetError() string {
+	if x, ok := x.GetResult().(*BatchLookupResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+type isBatchLookupResponse_Result interface {
+	isBatchLookupResponse_Result()
+}
+
+type BatchLookupResponse_Response struct {
+	Response *LookupResponse `protobuf:"bytes,2,opt,name=response,proto3,oneof"`
+}
+
+type BatchLookupResponse_Error struct {
+	// the error of the item
+	Error string `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+func (*BatchLookupResponse_Response) isBatchLookupResponse_Result() {}
+
+func (*BatchLookupResponse_Error) isBatchLookupResponse_Result() {}
+
+type ParseDomainRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// number of subdomain labels to keep
+	Level int32 `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"`
+}
+
+func (x *ParseDomainRequest) Reset() {
+	*x = ParseDomainRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filing_v1_filing_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParseDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseDomainRequest) ProtoMessage() {}
+
+func (x *ParseDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_filing_v1_filing_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseDomainRequest.ProtoReflect.Descriptor instead.
+func (*ParseDomainRequest) Descriptor() ([]byte, []int) {
+	return file_filing_v1_filing_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ParseDomainRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ParseDomainRequest) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+// DomainTLD mirrors tld.DomainTLDResp.
+type DomainTLD struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Link      string `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	Subdomain string `protobuf:"bytes,2,opt,name=subdomain,proto3" json:"subdomain,omitempty"`
+	Domain    string `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
+	Tld       string `protobuf:"bytes,4,opt,name=tld,proto3" json:"tld,omitempty"`
+	Label     int32  `protobuf:"varint,5,opt,name=label,proto3" json:"label,omitempty"`
+}
+
+func (x *DomainTLD) Reset() {
+	*x = DomainTLD{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filing_v1_filing_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainTLD) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainTLD) ProtoMessage() {}
+
+func (x *DomainTLD) ProtoReflect() protoreflect.Message {
+	mi := &file_filing_v1_filing_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainTLD.ProtoReflect.Descriptor instead.
+func (*DomainTLD) Descriptor() ([]byte, []int) {
+	return file_filing_v1_filing_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DomainTLD) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *DomainTLD) GetSubdomain() string {
+	if x != nil {
+		return x.Subdomain
+	}
+	return ""
+}
+
+func (x *DomainTLD) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *DomainTLD) GetTld() string {
+	if x != nil {
+		return x.Tld
+	}
+	return ""
+}
+
+func (x *DomainTLD) GetLabel() int32 {
+	if x != nil {
+		return x.Label
+	}
+	return 0
+}
+
+// QueryParams mirrors filling.QueryParams.
+type QueryParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EndRow           int32         `protobuf:"varint,1,opt,name=end_row,json=endRow,proto3" json:"end_row,omitempty"`
+	FirstPage        int32         `protobuf:"varint,2,opt,name=first_page,json=firstPage,proto3" json:"first_page,omitempty"`
+	HasNextPage      bool          `protobuf:"varint,3,opt,name=has_next_page,json=hasNextPage,proto3" json:"has_next_page,omitempty"`
+	HasPreviousPage  bool          `protobuf:"varint,4,opt,name=has_previous_page,json=hasPreviousPage,proto3" json:"has_previous_page,omitempty"`
+	IsFirstPage      bool          `protobuf:"varint,5,opt,name=is_first_page,json=isFirstPage,proto3" json:"is_first_page,omitempty"`
+	IsLastPage       bool          `protobuf:"varint,6,opt,name=is_last_page,json=isLastPage,proto3" json:"is_last_page,omitempty"`
+	LastPage         int32         `protobuf:"varint,7,opt,name=last_page,json=lastPage,proto3" json:"last_page,omitempty"`
+	List             []*DomainInfo `protobuf:"bytes,8,rep,name=list,proto3" json:"list,omitempty"`
+	NavigatePages    int32         `protobuf:"varint,9,opt,name=navigate_pages,json=navigatePages,proto3" json:"navigate_pages,omitempty"`
+	NavigatepageNums []int32       `protobuf:"varint,10,rep,packed,name=navigatepage_nums,json=navigatepageNums,proto3" json:"navigatepage_nums,omitempty"`
+	NextPage         int32         `protobuf:"varint,11,opt,name=next_page,json=nextPage,proto3" json:"next_page,omitempty"`
+	PageNum          int32         `protobuf:"varint,12,opt,name=page_num,json=pageNum,proto3" json:"page_num,omitempty"`
+	PageSize         int32         `protobuf:"varint,13,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Pages            int32         `protobuf:"varint,14,opt,name=pages,proto3" json:"pages,omitempty"`
+	PrePage          int32         `protobuf:"varint,15,opt,name=pre_page,json=prePage,proto3" json:"pre_page,omitempty"`
+	Size             int32         `protobuf:"varint,16,opt,name=size,proto3" json:"size,omitempty"`
+	StartRow         int32         `protobuf:"varint,17,opt,name=start_row,json=startRow,proto3" json:"start_row,omitempty"`
+	Total            int32         `protobuf:"varint,18,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *QueryParams) Reset() {
+	*x = QueryParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filing_v1_filing_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryParams) ProtoMessage() {}
+
+func (x *QueryParams) ProtoReflect() protoreflect.Message {
+	mi := &file_filing_v1_filing_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryParams.ProtoReflect.Descriptor instead.
+func (*QueryParams) Descriptor() ([]byte, []int) {
+	return file_filing_v1_filing_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *QueryParams) GetEndRow() int32 {
+	if x != nil {
+		return x.EndRow
+	}
+	return 0
+}
+
+func (x *QueryParams) GetFirstPage() int32 {
+	if x != nil {
+		return x.FirstPage
+	}
+	return 0
+}
+
+func (x *QueryParams) GetHasNextPage() bool {
+	if x != nil {
+		return x.HasNextPage
+	}
+	return false
+}
+
+func (x *QueryParams) GetHasPreviousPage() bool {
+	if x != nil {
+		return x.HasPreviousPage
+	}
+	return false
+}
+
+func (x *QueryParams) GetIsFirstPage() bool {
+	if x != nil {
+		return x.IsFirstPage
+	}
+	return false
+}
+
+func (x *QueryParams) GetIsLastPage() bool {
+	if x != nil {
+		return x.IsLastPage
+	}
+	return false
+}
+
+func (x *QueryParams) GetLastPage() int32 {
+	if x != nil {
+		return x.LastPage
+	}
+	return 0
+}
+
+func (x *QueryParams) GetList() []*DomainInfo {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+func (x *QueryParams) GetNavigatePages() int32 {
+	if x != nil {
+		return x.NavigatePages
+	}
+	return 0
+}
+
+func (x *QueryParams) GetNavigatepageNums() []int32 {
+	if x != nil {
+		return x.NavigatepageNums
+	}
+	return nil
+}
+
+func (x *QueryParams) GetNextPage() int32 {
+	if x != nil {
+		return x.NextPage
+	}
+	return 0
+}
+
+func (x *QueryParams) GetPageNum() int32 {
+	if x != nil {
+		return x.PageNum
+	}
+	return 0
+}
+
+func (x *QueryParams) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *QueryParams) GetPages() int32 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+func (x *QueryParams) GetPrePage() int32 {
+	if x != nil {
+		return x.PrePage
+	}
+	return 0
+}
+
+func (x *QueryParams) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *QueryParams) GetStartRow() int32 {
+	if x != nil {
+		return x.StartRow
+	}
+	return 0
+}
+
+func (x *QueryParams) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// DomainInfo mirrors filling.DomainInfo.
+type DomainInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContentTypeName  string `protobuf:"bytes,1,opt,name=content_type_name,json=contentTypeName,proto3" json:"content_type_name,omitempty"`
+	Domain           string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	DomainId         int64  `protobuf:"varint,3,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`
+	HomeUrl          string `protobuf:"bytes,4,opt,name=home_url,json=homeUrl,proto3" json:"home_url,omitempty"`
+	LeaderName       string `protobuf:"bytes,5,opt,name=leader_name,json=leaderName,proto3" json:"leader_name,omitempty"`
+	LimitAccess      string `protobuf:"bytes,6,opt,name=limit_access,json=limitAccess,proto3" json:"limit_access,omitempty"`
+	MainId           int64  `protobuf:"varint,7,opt,name=main_id,json=mainId,proto3" json:"main_id,omitempty"`
+	MainLicence      string `protobuf:"bytes,8,opt,name=main_licence,json=mainLicence,proto3" json:"main_licence,omitempty"`
+	NatureName       string `protobuf:"bytes,9,opt,name=nature_name,json=natureName,proto3" json:"nature_name,omitempty"`
+	ServiceId        int64  `protobuf:"varint,10,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	ServiceLicence   string `protobuf:"bytes,11,opt,name=service_licence,json=serviceLicence,proto3" json:"service_licence,omitempty"`
+	ServiceName      string `protobuf:"bytes,12,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	UnitName         string `protobuf:"bytes,13,opt,name=unit_name,json=unitName,proto3" json:"unit_name,omitempty"`
+	UpdateRecordTime string `protobuf:"bytes,14,opt,name=update_record_time,json=updateRecordTime,proto3" json:"update_record_time,omitempty"`
+}
+
+func (x *DomainInfo) Reset() {
+	*x = DomainInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filing_v1_filing_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainInfo) ProtoMessage() {}
+
+func (x *DomainInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_filing_v1_filing_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainInfo.ProtoReflect.Descriptor instead.
+func (*DomainInfo) Descriptor() ([]byte, []int) {
+	return file_filing_v1_filing_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DomainInfo) GetContentTypeName() string {
+	if x != nil {
+		return x.ContentTypeName
+	}
+	return ""
+}
+
+func (x *DomainInfo) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *DomainInfo) GetDomainId() int64 {
+	if x != nil {
+		return x.DomainId
+	}
+	return 0
+}
+
+func (x *DomainInfo) GetHomeUrl() string {
+	if x != nil {
+		return x.HomeUrl
+	}
+	return ""
+}
+
+func (x *DomainInfo) GetLeaderName() string {
+	if x != nil {
+		return x.LeaderName
+	}
+	return ""
+}
+
+func (x *DomainInfo) GetLimitAccess() string {
+	if x != nil {
+		return x.LimitAccess
+	}
+	return ""
+}
+
+func (x *DomainInfo) GetMainId() int64 {
+	if x != nil {
+		return x.MainId
+	}
+	return 0
+}
+
+func (x *DomainInfo) GetMainLicence() string {
+	if x != nil {
+		return x.MainLicence
+	}
+	return ""
+}
+
+func (x *DomainInfo) GetNatureName() string {
+	if x != nil {
+		return x.NatureName
+	}
+	return ""
+}
+
+func (x *DomainInfo) GetServiceId() int64 {
+	if x != nil {
+		return x.ServiceId
+	}
+	return 0
+}
+
+func (x *DomainInfo) GetServiceLicence() string {
+	if x != nil {
+		return x.ServiceLicence
+	}
+	return ""
+}
+
+func (x *DomainInfo) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *DomainInfo) GetUnitName() string {
+	if x != nil {
+		return x.UnitName
+	}
+	return ""
+}
+
+func (x *DomainInfo) GetUpdateRecordTime() string {
+	if x != nil {
+		return x.UpdateRecordTime
+	}
+	return ""
+}
+
+var File_filing_v1_filing_proto protoreflect.FileDescriptor
+
+var file_filing_v1_filing_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x2f, 0x76, 0x31, 0x2f, 0x66, 0x69, 0x6c, 0x69,
+	0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67,
+	0x2e, 0x76, 0x31, 0x22, 0xab, 0x01, 0x0a, 0x0d, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12,
+	0x14, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x04, 0x75, 0x6e, 0x69, 0x74, 0x12, 0x39, 0x0a, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x66, 0x69,
+	0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x70, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x22, 0x84, 0x01, 0x0a, 0x0e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x69, 0x6c, 0x69, 0x6e,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x44, 0x0a, 0x12, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e,
+	0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x86,
+	0x01, 0x0a, 0x13, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x37, 0x0a, 0x08,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75,
+	0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x08, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x08, 0x0a,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x3c, 0x0a, 0x12, 0x50, 0x61, 0x72, 0x73, 0x65,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x7d, 0x0a, 0x09, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x54,
+	0x4c, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x64, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x64, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03,
+	0x74, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x6c, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x22, 0xc4, 0x04, 0x0a, 0x0b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x5f, 0x72, 0x6f, 0x77, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x65, 0x6e, 0x64, 0x52, 0x6f, 0x77, 0x12, 0x1d, 0x0a,
+	0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x50, 0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x0d,
+	0x68, 0x61, 0x73, 0x5f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x68, 0x61, 0x73, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65,
+	0x12, 0x2a, 0x0a, 0x11, 0x68, 0x61, 0x73, 0x5f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
+	0x5f, 0x70, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x68, 0x61, 0x73,
+	0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x50, 0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x0d,
+	0x69, 0x73, 0x5f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x46, 0x69, 0x72, 0x73, 0x74, 0x50, 0x61, 0x67, 0x65,
+	0x12, 0x20, 0x0a, 0x0c, 0x69, 0x73, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x4c, 0x61, 0x73, 0x74, 0x50, 0x61,
+	0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x50, 0x61, 0x67, 0x65, 0x12,
+	0x29, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x6e, 0x61,
+	0x76, 0x69, 0x67, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0d, 0x6e, 0x61, 0x76, 0x69, 0x67, 0x61, 0x74, 0x65, 0x50, 0x61, 0x67, 0x65,
+	0x73, 0x12, 0x2b, 0x0a, 0x11, 0x6e, 0x61, 0x76, 0x69, 0x67, 0x61, 0x74, 0x65, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x05, 0x52, 0x10, 0x6e, 0x61,
+	0x76, 0x69, 0x67, 0x61, 0x74, 0x65, 0x70, 0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x73, 0x12, 0x1b,
+	0x0a, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70,
+	0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x72, 0x65,
+	0x5f, 0x70, 0x61, 0x67, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x72, 0x65,
+	0x50, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x5f, 0x72, 0x6f, 0x77, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x52, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x12,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0xdf, 0x03, 0x0a, 0x0a,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2a, 0x0a, 0x11, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1b,
+	0x0a, 0x09, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x68,
+	0x6f, 0x6d, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x68,
+	0x6f, 0x6d, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61,
+	0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x61, 0x69,
+	0x6e, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x6c, 0x69, 0x63, 0x65,
+	0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x69, 0x6e, 0x4c,
+	0x69, 0x63, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x5f, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x63, 0x65, 0x12,
+	0x21, 0x0a, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x6e, 0x69, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x2c, 0x0a, 0x12, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x2a, 0x96, 0x01,
+	0x0a, 0x0b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a,
+	0x18, 0x53, 0x45, 0x52, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x53,
+	0x45, 0x52, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x57, 0x45, 0x42, 0x53,
+	0x49, 0x54, 0x45, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x45, 0x52, 0x56, 0x49, 0x43, 0x45,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x50, 0x50, 0x10, 0x06, 0x12, 0x1d, 0x0a, 0x19, 0x53,
+	0x45, 0x52, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x49, 0x4e, 0x49,
+	0x5f, 0x50, 0x52, 0x4f, 0x47, 0x52, 0x41, 0x4d, 0x10, 0x07, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x45,
+	0x52, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x51, 0x55, 0x49, 0x43, 0x4b,
+	0x5f, 0x41, 0x50, 0x50, 0x10, 0x08, 0x32, 0xe2, 0x01, 0x0a, 0x0d, 0x46, 0x69, 0x6c, 0x69, 0x6e,
+	0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x4c, 0x6f, 0x6f, 0x6b,
+	0x75, 0x70, 0x12, 0x18, 0x2e, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x66,
+	0x69, 0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0b, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x12, 0x1d, 0x2e, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x73, 0x65,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1d, 0x2e, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x54, 0x4c, 0x44, 0x42, 0x36, 0x5a, 0x34, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x6d,
+	0x65, 0x2f, 0x69, 0x63, 0x70, 0x2d, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x66, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x66, 0x69, 0x6c, 0x69, 0x6e,
+	0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filing_v1_filing_proto_rawDescOnce sync.Once
+	file_filing_v1_filing_proto_rawDescData = file_filing_v1_filing_proto_rawDesc
+)
+
+func file_filing_v1_filing_proto_rawDescGZIP() []byte {
+	file_filing_v1_filing_proto_rawDescOnce.Do(func() {
+		file_filing_v1_filing_proto_rawDescData = protoimpl.X.CompressGZIP(file_filing_v1_filing_proto_rawDescData)
+	})
+	return file_filing_v1_filing_proto_rawDescData
+}
+
+var file_filing_v1_filing_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_filing_v1_filing_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_filing_v1_filing_proto_goTypes = []any{
+	(ServiceType)(0),            // 0: filing.v1.ServiceType
+	(*LookupRequest)(nil),       // 1: filing.v1.LookupRequest
+	(*LookupResponse)(nil),      // 2: filing.v1.LookupResponse
+	(*BatchLookupRequest)(nil),  // 3: filing.v1.BatchLookupRequest
+	(*BatchLookupResponse)(nil), // 4: filing.v1.BatchLookupResponse
+	(*ParseDomainRequest)(nil),  // 5: filing.v1.ParseDomainRequest
+	(*DomainTLD)(nil),           // 6: filing.v1.DomainTLD
+	(*QueryParams)(nil),         // 7: filing.v1.QueryParams
+	(*DomainInfo)(nil),          // 8: filing.v1.DomainInfo
+}
+var file_filing_v1_filing_proto_depIdxs = []int32{
+	0, // 0: filing.v1.LookupRequest.service_type:type_name -> filing.v1.ServiceType
+	7, // 1: filing.v1.LookupResponse.params:type_name -> filing.v1.QueryParams
+	1, // 2: filing.v1.BatchLookupRequest.items:type_name -> filing.v1.LookupRequest
+	2, // 3: filing.v1.BatchLookupResponse.response:type_name -> filing.v1.LookupResponse
+	8, // 4: filing.v1.QueryParams.list:type_name -> filing.v1.DomainInfo
+	1, // 5: filing.v1.FilingService.Lookup:input_type -> filing.v1.LookupRequest
+	3, // 6: filing.v1.FilingService.BatchLookup:input_type -> filing.v1.BatchLookupRequest
+	5, // 7: filing.v1.FilingService.ParseDomain:input_type -> filing.v1.ParseDomainRequest
+	2, // 8: filing.v1.FilingService.Lookup:output_type -> filing.v1.LookupResponse
+	4, // 9: filing.v1.FilingService.BatchLookup:output_type -> filing.v1.BatchLookupResponse
+	6, // 10: filing.v1.FilingService.ParseDomain:output_type -> filing.v1.DomainTLD
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_filing_v1_filing_proto_init() }
+func file_filing_v1_filing_proto_init() {
+	if File_filing_v1_filing_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filing_v1_filing_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*LookupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filing_v1_filing_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*LookupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filing_v1_filing_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*BatchLookupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filing_v1_filing_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*BatchLookupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filing_v1_filing_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ParseDomainRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filing_v1_filing_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*DomainTLD); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filing_v1_filing_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*QueryParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filing_v1_filing_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*DomainInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_filing_v1_filing_proto_msgTypes[0].OneofWrappers = []any{
+		(*LookupRequest_Domain)(nil),
+		(*LookupRequest_Unit)(nil),
+	}
+	file_filing_v1_filing_proto_msgTypes[3].OneofWrappers = []any{
+		(*BatchLookupResponse_Response)(nil),
+		(*BatchLookupResponse_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filing_v1_filing_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_filing_v1_filing_proto_goTypes,
+		DependencyIndexes: file_filing_v1_filing_proto_depIdxs,
+		EnumInfos:         file_filing_v1_filing_proto_enumTypes,
+		MessageInfos:      file_filing_v1_filing_proto_msgTypes,
+	}.Build()
+	File_filing_v1_filing_proto = out.File
+	file_filing_v1_filing_proto_rawDesc = nil
+	file_filing_v1_filing_proto_goTypes = nil
+	file_filing_v1_filing_proto_depIdxs = nil
+}
@@ -0,0 +1,240 @@
+// Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// You can obtain one at https://github.com/houseme/icp-filing.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: filing/v1/filing.proto
+
+package filingv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	FilingService_Lookup_FullMethodName      = "/filing.v1.FilingService/Lookup"
+	FilingService_BatchLookup_FullMethodName = "/filing.v1.FilingService/BatchLookup"
+	FilingService_ParseDomain_FullMethodName = "/filing.v1.FilingService/ParseDomain"
+)
+
+// FilingServiceClient is the client API for FilingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FilingService looks up ICP filings.
+type FilingServiceClient interface {
+	// Lookup the filings of a domain or a unit.
+	Lookup(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*LookupResponse, error)
+	// BatchLookup streams one response per request item as soon as it is done.
+	BatchLookup(ctx context.Context, in *BatchLookupRequest, opts ...grpc.CallOption) (FilingService_BatchLookupClient, error)
+	// ParseDomain parses the registrable domain of a URL, no upstream call is made.
+	ParseDomain(ctx context.Context, in *ParseDomainRequest, opts ...grpc.CallOption) (*DomainTLD, error)
+}
+
+type filingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFilingServiceClient(cc grpc.ClientConnInterface) FilingServiceClient {
+	return &filingServiceClient{cc}
+}
+
+func (c *filingServiceClient) Lookup(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*LookupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LookupResponse)
+	err := c.cc.Invoke(ctx, FilingService_Lookup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filingServiceClient) BatchLookup(ctx context.Context, in *BatchLookupRequest, opts ...grpc.CallOption) (FilingService_BatchLookupClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FilingService_ServiceDesc.Streams[0], FilingService_BatchLookup_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &filingServiceBatchLookupClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FilingService_BatchLookupClient interface {
+	Recv() (*BatchLookupResponse, error)
+	grpc.ClientStream
+}
+
+type filingServiceBatchLookupClient struct {
+	grpc.ClientStream
+}
+
+func (x *filingServiceBatchLookupClient) Recv() (*BatchLookupResponse, error) {
+	m := new(BatchLookupResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *filingServiceClient) ParseDomain(ctx context.Context, in *ParseDomainRequest, opts ...grpc.CallOption) (*DomainTLD, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DomainTLD)
+	err := c.cc.Invoke(ctx, FilingService_ParseDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FilingServiceServer is the server API for FilingService service.
+// All implementations must embed UnimplementedFilingServiceServer
+// for forward compatibility
+//
+// FilingService looks up ICP filings.
+type FilingServiceServer interface {
+	// Lookup the filings of a domain or a unit.
+	Lookup(context.Context, *LookupRequest) (*LookupResponse, error)
+	// BatchLookup streams one response per request item as soon as it is done.
+	BatchLookup(*BatchLookupRequest, FilingService_BatchLookupServer) error
+	// ParseDomain parses the registrable domain of a URL, no upstream call is made.
+	ParseDomain(context.Context, *ParseDomainRequest) (*DomainTLD, error)
+	mustEmbedUnimplementedFilingServiceServer()
+}
+
+// UnimplementedFilingServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFilingServiceServer struct {
+}
+
+func (UnimplementedFilingServiceServer) Lookup(context.Context, *LookupRequest) (*LookupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lookup not implemented")
+}
+func (UnimplementedFilingServiceServer) BatchLookup(*BatchLookupRequest, FilingService_BatchLookupServer) error {
+	return status.Errorf(codes.Unimplemented, "method BatchLookup not implemented")
+}
+func (UnimplementedFilingServiceServer) ParseDomain(context.Context, *ParseDomainRequest) (*DomainTLD, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ParseDomain not implemented")
+}
+func (UnimplementedFilingServiceServer) mustEmbedUnimplementedFilingServiceServer() {}
+
+// UnsafeFilingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FilingServiceServer will
+// result in compilation errors.
+type UnsafeFilingServiceServer interface {
+	mustEmbedUnimplementedFilingServiceServer()
+}
+
+func RegisterFilingServiceServer(s grpc.ServiceRegistrar, srv FilingServiceServer) {
+	s.RegisterService(&FilingService_ServiceDesc, srv)
+}
+
+func _FilingService_Lookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilingServiceServer).Lookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FilingService_Lookup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilingServiceServer).Lookup(ctx, req.(*LookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FilingService_BatchLookup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchLookupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FilingServiceServer).BatchLookup(m, &filingServiceBatchLookupServer{ServerStream: stream})
+}
+
+type FilingService_BatchLookupServer interface {
+	Send(*BatchLookupResponse) error
+	grpc.ServerStream
+}
+
+type filingServiceBatchLookupServer struct {
+	grpc.ServerStream
+}
+
+func (x *filingServiceBatchLookupServer) Send(m *BatchLookupResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FilingService_ParseDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParseDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilingServiceServer).ParseDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FilingService_ParseDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilingServiceServer).ParseDomain(ctx, req.(*ParseDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FilingService_ServiceDesc is the grpc.ServiceDesc for FilingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FilingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "filing.v1.FilingService",
+	HandlerType: (*FilingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Lookup",
+			Handler:    _FilingService_Lookup_Handler,
+		},
+		{
+			MethodName: "ParseDomain",
+			Handler:    _FilingService_ParseDomain_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BatchLookup",
+			Handler:       _FilingService_BatchLookup_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "filing/v1/filing.proto",
+}
@@ -0,0 +1,132 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"container/list"
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores successful query responses by request, the cached responses
+// are shared and must not be modified. Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(ctx context.Context, key string) (*QueryResponse, bool)
+	Set(ctx context.Context, key string, resp *QueryResponse)
+}
+
+// RateLimiter paces the upstream calls, *rate.Limiter of golang.org/x/time/rate satisfies it.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// WithCache is the option for the response cache.
+func WithCache(c Cache) Option {
+	return func(o *options) {
+		o.Cache = c
+	}
+}
+
+// WithRateLimiter is the option for the rate limiter, it is waited for before every upstream call.
+func WithRateLimiter(l RateLimiter) Option {
+	return func(o *options) {
+		o.RateLimiter = l
+	}
+}
+
+// cacheKey return the cache key of req
+func cacheKey(req *QueryRequest) string {
+	return strconv.Itoa(int(req.ServiceType)) + "|" + strconv.Itoa(req.PageNum) + "|" + strconv.Itoa(req.PageSize) + "|" + strings.ToLower(strings.TrimSpace(req.UnitName))
+}
+
+// MemoryCache is an in-memory Cache evicting the least recently used entry
+type MemoryCache struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	size  int
+	items map[string]*list.Element
+	order *list.List
+	now   func() time.Time
+}
+
+// cacheEntry is an element of MemoryCache.order
+type cacheEntry struct {
+	key     string
+	resp    *QueryResponse
+	expires time.Time
+}
+
+// NewMemoryCache return a MemoryCache keeping at most size entries for ttl,
+// size is not bounded when it is not positive.
+func NewMemoryCache(ttl time.Duration, size int) *MemoryCache {
+	return &MemoryCache{
+		ttl:   ttl,
+		size:  size,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+		now:   time.Now,
+	}
+}
+
+// Get implements Cache
+func (c *MemoryCache) Get(_ context.Context, key string) (*QueryResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.resp, true
+}
+
+// Set implements Cache
+func (c *MemoryCache) Set(_ context.Context, key string, resp *QueryResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expires := c.now().Add(c.ttl)
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.resp, entry.expires = resp, expires
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.items[key] = c.order.PushFront(&cacheEntry{key: key, resp: resp, expires: expires})
+	if c.size > 0 && c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// Len return the number of entries, expired ones included
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,168 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/houseme/icp-filing/utility/request"
+)
+
+func TestMemoryCache(t *testing.T) {
+	var (
+		ctx = context.Background()
+		now = time.Unix(0, 0)
+		c   = NewMemoryCache(time.Minute, 2)
+	)
+	c.now = func() time.Time { return now }
+
+	a, b, d := &QueryResponse{Code: 1}, &QueryResponse{Code: 2}, &QueryResponse{Code: 3}
+	c.Set(ctx, "a", a)
+	c.Set(ctx, "b", b)
+	if got, ok := c.Get(ctx, "a"); !ok || got != a {
+		t.Fatalf("Get(a) = %v, %v, want a", got, ok)
+	}
+	// a was used last, b is evicted
+	c.Set(ctx, "d", d)
+	if _, ok := c.Get(ctx, "b"); ok {
+		t.Errorf("Get(b) hit, want it evicted")
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", c.Len())
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := c.Get(ctx, "a"); ok {
+		t.Errorf("Get(a) hit after the ttl")
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d, want 1 after the expired entry is dropped", c.Len())
+	}
+}
+
+// countLimiter counts the waits and fails with err
+type countLimiter struct {
+	waits atomic.Int32
+	err   error
+}
+
+func (l *countLimiter) Wait(context.Context) error {
+	l.waits.Add(1)
+	return l.err
+}
+
+func TestFilling_CacheAndToken(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		auths   atomic.Int32
+		queries atomic.Int32
+		limiter = &countLimiter{}
+	)
+	doer := queryDoer(func(req *http.Request) (int, string, string) {
+		queries.Add(1)
+		return http.StatusOK, "application/json", testQueryBody
+	})
+	counted := func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/auth") {
+			auths.Add(1)
+		}
+		return doer.Do(req)
+	}
+	f := New(ctx, WithDoer(request.DoerFunc(counted)), WithCache(NewMemoryCache(time.Minute, 0)), WithRateLimiter(limiter))
+
+	for _, unit := range []string{"baidu.com", "BAIDU.com", "qq.com"} {
+		if _, err := f.DomainFilling(ctx, &QueryRequest{UnitName: unit, ServiceType: 1, PageNum: 1, PageSize: 10}); err != nil {
+			t.Fatalf("DomainFilling(%s) error = %v", unit, err)
+		}
+	}
+	if got := auths.Load(); got != 1 {
+		t.Errorf("authorize calls = %d, want 1 as the token is reused", got)
+	}
+	if got := queries.Load(); got != 2 {
+		t.Errorf("queries = %d, want 2 as BAIDU.com is cached", got)
+	}
+	if got := limiter.waits.Load(); got != 3 {
+		t.Errorf("limiter waits = %d, want 3", got)
+	}
+
+	req := &QueryRequest{UnitName: "baidu.com", ServiceType: 1, PageNum: 1, PageSize: 10}
+	resp, err := f.DomainFilling(ctx, req)
+	if err != nil {
+		t.Fatalf("DomainFilling() error = %v", err)
+	}
+	want := len(resp.Params.List)
+	resp.Params.List, resp.Params.Total = resp.Params.List[:0], 0
+	if resp, err = f.DomainFilling(ctx, req); err != nil || len(resp.Params.List) != want || resp.Params.Total == 0 {
+		t.Errorf("DomainFilling() after changing the cached response = %v, %v, want %d records", resp, err, want)
+	}
+
+	limiter.err = errors.New("rate limited")
+	if _, err := f.DomainFilling(ctx, &QueryRequest{UnitName: "163.com"}); !errors.Is(err, limiter.err) {
+		t.Errorf("DomainFilling() error = %v, want the limiter error", err)
+	}
+}
+
+func TestFilling_ConcurrentTokenExpired(t *testing.T) {
+	const callers = 8
+	var (
+		ctx     = context.Background()
+		auths   atomic.Int32
+		expired atomic.Int32
+		ready   = make(chan struct{})
+	)
+	// every caller is answered 401 with the first token, once they all asked
+	doer := request.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		body := testQueryBody
+		if strings.HasSuffix(req.URL.Path, "/auth") {
+			body = fmt.Sprintf(`{"code":200,"success":true,"params":{"bussiness":"token-%d","expire":300000}}`, auths.Add(1))
+		} else if req.Header.Get("Token") == "token-1" {
+			if expired.Add(1) == callers {
+				close(ready)
+			}
+			<-ready
+			body = `{"code":401,"msg":"token expired","success":false}`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	f := New(ctx, WithDoer(doer))
+
+	var wg sync.WaitGroup
+	for n := 0; n < callers; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if resp, err := f.DomainFilling(ctx, &QueryRequest{UnitName: "baidu.com"}); err != nil || !resp.Success {
+				t.Errorf("DomainFilling() = %v, %v", resp, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := auths.Load(); got != 2 {
+		t.Errorf("authorize calls = %d, want 2 as one refresh serves every expired query", got)
+	}
+}
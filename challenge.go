@@ -0,0 +1,204 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/houseme/icp-filing/utility/request"
+)
+
+// ErrChallengeRequired is returned when the upstream blocks the client with a
+// WAF page or asks it to complete a captcha before answering.
+var ErrChallengeRequired = errors.New("challenge required")
+
+var (
+	// challengeStatus are the HTTP status codes the MIIT WAF answers with
+	challengeStatus = map[int]struct{}{
+		http.StatusForbidden:          {},
+		http.StatusPreconditionFailed: {},
+		521:                           {},
+	}
+
+	// challengeMarkers are lower-cased fragments found in challenge pages and messages
+	challengeMarkers = []string{"captcha", "waf", "验证码", "滑动验证", "滑块", "安全验证", "请完成验证", "访问验证"}
+)
+
+// maxChallengeSize is the largest successful JSON answer checked for a challenge message
+const maxChallengeSize = 4 << 10
+
+// Challenge describes a challenge returned by the upstream
+type Challenge struct {
+	Path        string
+	StatusCode  int
+	ContentType string
+	// Code and Msg are the business code and message of a JSON challenge
+	Code   int
+	Msg    string
+	Header http.Header
+	// Payload is the body, re-encoded when the answer was decoded while read
+	Payload []byte
+}
+
+// ChallengeError is the error carrying a Challenge,
+// errors.Is(err, ErrChallengeRequired) reports true for it.
+type ChallengeError struct {
+	Challenge *Challenge
+	// Err is the underlying error, such as *request.HTTPError, if any
+	Err error
+}
+
+// Error implements error
+func (e *ChallengeError) Error() string {
+	msg := ErrChallengeRequired.Error() + " : path=" + e.Challenge.Path + " , statusCode=" + strconv.Itoa(e.Challenge.StatusCode)
+	if e.Challenge.Msg != "" {
+		msg += " , msg=" + e.Challenge.Msg
+	}
+	return msg
+}
+
+// Is reports whether target is ErrChallengeRequired
+func (e *ChallengeError) Is(target error) bool {
+	return target == ErrChallengeRequired
+}
+
+// Unwrap return the underlying error
+func (e *ChallengeError) Unwrap() error {
+	return e.Err
+}
+
+// ChallengeSolver completes a challenge on behalf of the client. The returned
+// headers are sent with the retried call and with every later upstream call.
+type ChallengeSolver interface {
+	Solve(ctx context.Context, c *Challenge) (headers map[string]string, err error)
+}
+
+// ChallengeSolverFunc is an adapter to allow the use of ordinary functions as ChallengeSolver
+type ChallengeSolverFunc func(ctx context.Context, c *Challenge) (map[string]string, error)
+
+// Solve calls f(ctx, c)
+func (f ChallengeSolverFunc) Solve(ctx context.Context, c *Challenge) (map[string]string, error) {
+	return f(ctx, c)
+}
+
+// WithChallengeSolver is the option for the challenge solver.
+func WithChallengeSolver(solver ChallengeSolver) Option {
+	return func(o *options) {
+		o.ChallengeSolver = solver
+	}
+}
+
+// detectChallenge return the challenge carried by resp, or nil
+func detectChallenge(in *ParamInput, resp *request.Response) *Challenge {
+	if resp == nil {
+		return nil
+	}
+	if m, ok := resp.Value.(challengeMessage); ok {
+		if code, msg := m.message(); hasChallengeMarker(msg) {
+			payload, _ := json.Marshal(resp.Value)
+			return &Challenge{
+				Path:        in.Path,
+				StatusCode:  resp.StatusCode,
+				ContentType: resp.Header.Get("Content-Type"),
+				Header:      resp.Header,
+				Code:        code,
+				Msg:         msg,
+				Payload:     payload,
+			}
+		}
+		return nil
+	}
+	var (
+		contentType = resp.Header.Get("Content-Type")
+		body        = bytes.TrimSpace(resp.Body)
+		c           = &Challenge{
+			Path:        in.Path,
+			StatusCode:  resp.StatusCode,
+			ContentType: contentType,
+			Header:      resp.Header,
+			Payload:     resp.Body,
+		}
+	)
+
+	// an HTML page where JSON is expected is a block page when the WAF status
+	// says so, or when a successful answer carries a marker. Other pages, such
+	// as a 502 of a gateway, are plain HTTP errors.
+	if strings.Contains(strings.ToLower(contentType), "text/html") || bytes.HasPrefix(body, []byte("<")) {
+		if _, ok := challengeStatus[resp.StatusCode]; ok {
+			return c
+		}
+		if resp.StatusCode == http.StatusOK && hasChallengeMarker(string(body)) {
+			return c
+		}
+		return nil
+	}
+
+	// a large successful answer is a result, not a challenge, it is not
+	// parsed twice
+	if resp.StatusCode == http.StatusOK && len(body) > maxChallengeSize {
+		return nil
+	}
+	var msg struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.Unmarshal(body, &msg); err != nil {
+		if _, ok := challengeStatus[resp.StatusCode]; ok && hasChallengeMarker(string(body)) {
+			return c
+		}
+		return nil
+	}
+	if hasChallengeMarker(msg.Msg) {
+		c.Code, c.Msg = msg.Code, msg.Msg
+		return c
+	}
+	return nil
+}
+
+// challengeMessage is implemented by the responses send decodes while reading
+type challengeMessage interface {
+	message() (code int, msg string)
+}
+
+// message implements challengeMessage
+func (r *QueryResponse) message() (int, string) {
+	return r.Code, r.Msg
+}
+
+// message implements challengeMessage
+func (r *AuthorizeResponse) message() (int, string) {
+	return r.Code, r.Msg
+}
+
+// hasChallengeMarker reports whether s contains a known challenge marker
+func hasChallengeMarker(s string) bool {
+	s = strings.ToLower(s)
+	for _, marker := range challengeMarkers {
+		if strings.Contains(s, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/houseme/icp-filing/utility/logger"
+	"github.com/houseme/icp-filing/utility/request"
+)
+
+const (
+	testWAFPage     = `<html><head><title>安全验证</title></head><body>请完成验证后继续访问</body></html>`
+	testCaptchaBody = `{"code":500,"msg":"请先完成滑动验证码校验","success":false}`
+)
+
+// queryDoer answers the authorize call and lets query answer the query call
+func queryDoer(query func(req *http.Request) (int, string, string)) request.Doer {
+	return request.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		status, contentType, body := http.StatusOK, "application/json", testAuthBody
+		if strings.HasSuffix(req.URL.Path, queryPath) {
+			status, contentType, body = query(req)
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": {contentType}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+}
+
+// recordsDoer answers the query call with the page asked of the records
+// that records return for the request
+func recordsDoer(records func(in QueryRequest) []*DomainInfo) request.Doer {
+	return queryDoer(func(req *http.Request) (int, string, string) {
+		var in QueryRequest
+		_ = json.NewDecoder(req.Body).Decode(&in)
+		all := records(in)
+		params := &QueryParams{PageNum: in.PageNum, PageSize: in.PageSize, Total: len(all)}
+		start := min(max(in.PageNum-1, 0)*in.PageSize, len(all))
+		end := min(start+in.PageSize, len(all))
+		params.List, params.HasNextPage = all[start:end], end < len(all)
+		b, _ := json.Marshal(&QueryResponse{Code: 200, Success: true, Params: params})
+		return http.StatusOK, "application/json", string(b)
+	})
+}
+
+func TestFilling_Challenge(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		wantCode    int
+		wantPayload string // the body when empty
+		wantHTTPErr bool
+	}{
+		{name: "html with 200", status: http.StatusOK, contentType: "text/html; charset=utf-8", body: testWAFPage},
+		{name: "html with 412", status: http.StatusPreconditionFailed, contentType: "text/html", body: testWAFPage, wantHTTPErr: true},
+		{name: "html without content type", status: http.StatusOK, contentType: "", body: "\n" + testWAFPage},
+		{name: "json captcha", status: http.StatusOK, contentType: "application/json", body: testCaptchaBody, wantCode: 500,
+			wantPayload: `{"code":500,"msg":"请先完成滑动验证码校验","success":false,"params":null}`},
+	}
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(ctx, WithLogger(logger.NewDefaultLogger()), WithDoer(queryDoer(func(*http.Request) (int, string, string) {
+				return tt.status, tt.contentType, tt.body
+			})))
+			_, err := f.DomainFilling(ctx, &QueryRequest{UnitName: "baidu.com", ServiceType: 1})
+			if !errors.Is(err, ErrChallengeRequired) {
+				t.Fatalf("DomainFilling() error = %v, want ErrChallengeRequired", err)
+			}
+			var challengeErr *ChallengeError
+			if !errors.As(err, &challengeErr) {
+				t.Fatalf("DomainFilling() error = %T, want *ChallengeError", err)
+			}
+			c, wantPayload := challengeErr.Challenge, tt.wantPayload
+			if wantPayload == "" {
+				wantPayload = tt.body
+			}
+			if c.Path != queryPath || c.StatusCode != tt.status || c.Code != tt.wantCode || string(c.Payload) != wantPayload {
+				t.Errorf("Challenge = %+v", c)
+			}
+			var httpErr *request.HTTPError
+			if errors.As(err, &httpErr) != tt.wantHTTPErr {
+				t.Errorf("errors.As(*request.HTTPError) = %v, want %v", !tt.wantHTTPErr, tt.wantHTTPErr)
+			}
+		})
+	}
+}
+
+func TestFilling_NotChallenge(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		wantHTTPErr bool
+	}{
+		{name: "gateway page", status: http.StatusBadGateway, contentType: "text/html", body: `<html><head><title>502 Bad Gateway</title></head><body>nginx</body></html>`, wantHTTPErr: true},
+		{name: "error page", status: http.StatusInternalServerError, contentType: "", body: "<html><body>internal error</body></html>", wantHTTPErr: true},
+		{name: "html with 200", status: http.StatusOK, contentType: "text/html", body: "<html><body>maintenance</body></html>"},
+	}
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solver := ChallengeSolverFunc(func(context.Context, *Challenge) (map[string]string, error) {
+				t.Error("solver called for a plain error page")
+				return nil, nil
+			})
+			f := New(ctx, WithChallengeSolver(solver), WithDoer(queryDoer(func(*http.Request) (int, string, string) {
+				return tt.status, tt.contentType, tt.body
+			})))
+			_, err := f.DomainFilling(ctx, &QueryRequest{UnitName: "baidu.com", ServiceType: 1})
+			if err == nil || errors.Is(err, ErrChallengeRequired) {
+				t.Fatalf("DomainFilling() error = %v, want a non challenge error", err)
+			}
+			var httpErr *request.HTTPError
+			if errors.As(err, &httpErr) != tt.wantHTTPErr {
+				t.Errorf("DomainFilling() error = %v, want *request.HTTPError %v", err, tt.wantHTTPErr)
+			}
+		})
+	}
+}
+
+func TestFilling_ChallengeSolver(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		solves int
+	)
+	doer := queryDoer(func(req *http.Request) (int, string, string) {
+		if req.Header.Get("Sign") != "solved" {
+			return http.StatusOK, "application/json", testCaptchaBody
+		}
+		return http.StatusOK, "application/json", testQueryBody
+	})
+	solver := ChallengeSolverFunc(func(_ context.Context, c *Challenge) (map[string]string, error) {
+		solves++
+		if c.Msg == "" {
+			t.Errorf("Solve() challenge without message: %+v", c)
+		}
+		return map[string]string{"Sign": "solved"}, nil
+	})
+	f := New(ctx, WithLogger(logger.NewDefaultLogger()), WithDoer(doer), WithChallengeSolver(solver))
+
+	for n := 0; n < 2; n++ {
+		got, err := f.DomainFilling(ctx, &QueryRequest{UnitName: "baidu.com", ServiceType: 1})
+		if err != nil {
+			t.Fatalf("DomainFilling() error = %v", err)
+		}
+		if got.Params == nil || len(got.Params.List) != 1 {
+			t.Errorf("DomainFilling() got = %v", got)
+		}
+	}
+	if solves != 1 {
+		t.Errorf("Solve() called %d times, want 1", solves)
+	}
+}
+
+func TestFilling_ChallengeSolverError(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		solverErr = errors.New("solver failed")
+	)
+	doer := queryDoer(func(*http.Request) (int, string, string) {
+		return http.StatusOK, "application/json", testCaptchaBody
+	})
+	solver := ChallengeSolverFunc(func(context.Context, *Challenge) (map[string]string, error) {
+		return nil, solverErr
+	})
+	f := New(ctx, WithLogger(logger.NewDefaultLogger()), WithDoer(doer), WithChallengeSolver(solver))
+
+	_, err := f.DomainFilling(ctx, &QueryRequest{UnitName: "baidu.com", ServiceType: 1})
+	if !errors.Is(err, ErrChallengeRequired) || !errors.Is(err, solverErr) {
+		t.Errorf("DomainFilling() error = %v, want ErrChallengeRequired wrapping the solver error", err)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/houseme/icp-filing/tld"
+)
+
+// Source tells where a verdict comes from
+type Source string
+
+// Sources of a verdict
+const (
+	SourceLive  Source = "live"
+	SourceCache Source = "cache"
+)
+
+// ErrInvalidDomain is returned by Check for a host without registrable domain
+var ErrInvalidDomain = errors.New("invalid domain")
+
+// Verdict is the filing status of a host
+type Verdict struct {
+	// Filed reports whether the registrable domain has a filing record
+	Filed bool `json:"filed"`
+	// Domain is the registrable domain of the host
+	Domain string `json:"domain"`
+	// Licence is the service licence of the record, such as 京ICP证030173号-1
+	Licence string `json:"licence,omitempty"`
+	// Unit is the unit name of the record
+	Unit string `json:"unit,omitempty"`
+	// Nature is the nature of the unit
+	Nature Nature `json:"nature"`
+	// CheckedAt is the time of the check
+	CheckedAt time.Time `json:"checkedAt"`
+	// Source tells whether the upstream answer was cached
+	Source Source `json:"source"`
+	// Record is the matching record, nil when the domain is not filed
+	Record *DomainInfo `json:"record,omitempty"`
+	// Response is the upstream answer the verdict is based on
+	Response *QueryResponse `json:"-"`
+}
+
+// Check return whether the registrable domain of hostOrURL, such as
+// https://www.baidu.com/index.html, is filed. Only a record of exactly that
+// domain counts, the error wraps ErrInvalidDomain when there is no such domain.
+func (i *Filling) Check(ctx context.Context, hostOrURL string) (*Verdict, error) {
+	resp, err := i.DomainTLD(ctx, tld.Hostname(hostOrURL), 0)
+	if err != nil || resp.Domain == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidDomain, hostOrURL)
+	}
+	v := &Verdict{Domain: resp.Domain, CheckedAt: now()}
+
+	qr, source, err := i.domainFilling(ctx, NewQuery(v.Domain).Size(MaxPageSize))
+	if err != nil {
+		return nil, err
+	}
+	if qr == nil {
+		return nil, errors.New("response is nil")
+	}
+	if !qr.Success {
+		return nil, errors.New("code: " + strconv.Itoa(qr.Code) + " errMsg: " + qr.Msg)
+	}
+	v.Source, v.Response = source, qr
+	if qr.Params != nil {
+		for _, info := range qr.Params.List {
+			if info != nil && strings.EqualFold(strings.TrimSpace(info.Domain), v.Domain) {
+				v.Filed, v.Record = true, info
+				v.Licence, v.Unit, v.Nature = info.ServiceLicence, info.UnitName, info.Nature()
+				break
+			}
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,94 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFilling_Check(t *testing.T) {
+	ctx := context.Background()
+	var queries atomic.Int32
+	f := New(ctx, WithCache(NewMemoryCache(time.Minute, 0)), WithDoer(recordsDoer(func(in QueryRequest) []*DomainInfo {
+		queries.Add(1)
+		switch in.UnitName {
+		case "baidu.com":
+			return []*DomainInfo{
+				{Domain: "baidu.com.cn", UnitName: "其他公司"},
+				{Domain: " BAIDU.com ", UnitName: "北京百度网讯科技有限公司", NatureName: "企业", ServiceLicence: "京ICP证030173号-1"},
+			}
+		case "example.com":
+			return []*DomainInfo{{Domain: "example.com.cn", UnitName: "其他公司"}}
+		}
+		return nil
+	})))
+
+	tests := []struct {
+		name    string
+		host    string
+		filed   bool
+		domain  string
+		source  Source
+		queries int32
+		wantErr error
+	}{
+		{name: "url", host: "https://www.baidu.com/index.html", filed: true, domain: "baidu.com", source: SourceLive, queries: 1},
+		{name: "cached", host: "map.baidu.com", filed: true, domain: "baidu.com", source: SourceCache, queries: 1},
+		{name: "fqdn url", host: "https://www.baidu.com./index.html", filed: true, domain: "baidu.com", source: SourceCache, queries: 1},
+		{name: "similar domain only", host: "example.com", domain: "example.com", source: SourceLive, queries: 2},
+		{name: "no records", host: "www.qq.com:443", domain: "qq.com", source: SourceLive, queries: 3},
+		{name: "invalid", host: "localhost", queries: 3, wantErr: ErrInvalidDomain},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := f.Check(ctx, tt.host)
+			if got := queries.Load(); got != tt.queries {
+				t.Errorf("queries = %d, want %d", got, tt.queries)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Check() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if v.Filed != tt.filed || v.Domain != tt.domain || v.Source != tt.source {
+				t.Errorf("Check() = filed %v domain %q source %q, want %v %q %q", v.Filed, v.Domain, v.Source, tt.filed, tt.domain, tt.source)
+			}
+			if v.CheckedAt.IsZero() || v.Response == nil {
+				t.Errorf("Check() CheckedAt = %v, Response = %v", v.CheckedAt, v.Response)
+			}
+			if !tt.filed {
+				if v.Record != nil || v.Licence != "" {
+					t.Errorf("Check() Record = %v, Licence = %q, want none", v.Record, v.Licence)
+				}
+				return
+			}
+			if v.Licence != "京ICP证030173号-1" || v.Unit != "北京百度网讯科技有限公司" || v.Nature.Kind != NatureEnterprise || v.Record == nil {
+				t.Errorf("Check() = %+v", v)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+// Command icp-server serves ICP filing lookups over HTTP with one shared
+// client, so the token, the cache and the upstream quota are pooled.
+//
+//	icp-server -addr :8080 -grpc-addr :9090 -rate 2 -burst 4 -cache-ttl 1h
+//
+// The API is described in package server, Prometheus metrics are served on
+// /metrics and the gRPC FilingService on -grpc-addr.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+
+	filing "github.com/houseme/icp-filing"
+	filingv1 "github.com/houseme/icp-filing/api/filing/v1"
+	"github.com/houseme/icp-filing/metrics"
+	"github.com/houseme/icp-filing/server"
+	"github.com/houseme/icp-filing/utility/request"
+)
+
+// config is the command line configuration
+type config struct {
+	addr      string
+	rate      float64
+	burst     int
+	cacheTTL  time.Duration
+	cacheSize int
+	timeout   time.Duration
+	workers   int
+	grpcAddr  string
+}
+
+func main() {
+	var cfg config
+	flag.StringVar(&cfg.addr, "addr", ":8080", "listen address")
+	flag.Float64Var(&cfg.rate, "rate", 2, "upstream calls per second")
+	flag.IntVar(&cfg.burst, "burst", 4, "upstream calls allowed in a burst")
+	flag.DurationVar(&cfg.cacheTTL, "cache-ttl", time.Hour, "lifetime of cached responses, 0 disables the cache")
+	flag.IntVar(&cfg.cacheSize, "cache-size", 10000, "number of cached responses")
+	flag.DurationVar(&cfg.timeout, "timeout", server.DefaultTimeout, "timeout of one lookup")
+	flag.IntVar(&cfg.workers, "workers", server.DefaultWorkers, "concurrent lookups of a batch request")
+	flag.StringVar(&cfg.grpcAddr, "grpc-addr", "", "gRPC listen address, empty disables gRPC")
+	flag.Parse()
+
+	log := slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	if err := run(&cfg, log); err != nil {
+		log.Error("icp-server stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+// run serve until SIGINT or SIGTERM, then shut down gracefully
+func run(cfg *config, log *slog.Logger) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	m, err := metrics.NewPrometheus(reg, "")
+	if err != nil {
+		return err
+	}
+	opts := []filing.Option{
+		filing.WithDoer(request.DefaultClient()),
+		filing.WithSlog(log),
+		filing.WithMetrics(m),
+		filing.WithRateLimiter(rate.NewLimiter(rate.Limit(cfg.rate), cfg.burst)),
+	}
+	if cfg.cacheTTL > 0 {
+		opts = append(opts, filing.WithCache(filing.NewMemoryCache(cfg.cacheTTL, cfg.cacheSize)))
+	}
+	f, err := filing.NewWithError(ctx, opts...)
+	if err != nil {
+		return err
+	}
+
+	serverOpts := []server.Option{server.WithLogger(log), server.WithTimeout(cfg.timeout), server.WithWorkers(cfg.workers)}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	mux.Handle("/", server.New(f, serverOpts...))
+	srv := &http.Server{
+		Addr:              cfg.addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errc := make(chan error, 2)
+	if cfg.grpcAddr != "" {
+		lis, err := net.Listen("tcp", cfg.grpcAddr)
+		if err != nil {
+			return err
+		}
+		gs := grpc.NewServer()
+		filingv1.RegisterFilingServiceServer(gs, server.NewGRPC(f, serverOpts...))
+		defer gs.GracefulStop()
+		go func() {
+			log.Info("icp-server gRPC listening", "addr", cfg.grpcAddr)
+			errc <- gs.Serve(lis)
+		}()
+	}
+	go func() {
+		log.Info("icp-server listening", "addr", cfg.addr)
+		errc <- srv.ListenAndServe()
+	}()
+	select {
+	case err = <-errc:
+		return err
+	case <-ctx.Done():
+	}
+	shutdown, cancel := context.WithTimeout(context.Background(), cfg.timeout)
+	defer cancel()
+	if err = srv.Shutdown(shutdown); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
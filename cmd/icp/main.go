@@ -0,0 +1,314 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+// Command icp looks up ICP filings from the command line.
+//
+//	icp query [flags] <domain|company>...
+//	icp tld <url>...
+//	icp batch [flags] -f domains.txt
+//
+// The exit code is 0 when every lookup is filed, 1 when one is not filed,
+// 2 when a lookup fails and 64 on usage errors.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	filing "github.com/houseme/icp-filing"
+	"github.com/houseme/icp-filing/tld"
+)
+
+// exit codes
+const (
+	exitOK       = 0
+	exitFiled    = exitOK
+	exitNotFiled = 1
+	exitError    = 2
+	exitUsage    = 64
+)
+
+const usage = `Usage:
+  icp query [flags] <domain|company>...   look up the filings of domains or companies
+  icp tld <url>...                        parse the registrable domain of URLs
+  icp batch [flags] -f domains.txt        look up every line of a file, - for stdin
+
+Run "icp <command> -h" for the flags of a command.
+Exit codes: 0 filed, 1 not filed, 2 error, 64 usage.
+`
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run execute the command line args and return the exit code, opts are
+// appended to the options of the filling client
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer, opts ...filing.Option) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	switch args[0] {
+	case "query":
+		return runQuery(ctx, args[1:], stdout, stderr, opts)
+	case "batch":
+		return runBatch(ctx, args[1:], stdin, stdout, stderr, opts)
+	case "tld":
+		return runTLD(ctx, args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	default:
+		fmt.Fprintf(stderr, "icp: unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+}
+
+// queryFlags are the flags shared by query and batch
+type queryFlags struct {
+	serviceType filing.ServiceType
+	page        int
+	size        int
+	format      string
+	timeout     time.Duration
+	proxy       string
+	// paged is set when -page or -size is given
+	paged bool
+}
+
+// register add the flags to fs
+func (q *queryFlags) register(fs *flag.FlagSet) {
+	fs.TextVar(&q.serviceType, "type", filing.ServiceWebsite, "service type: website, app, miniprogram or quickapp, or 1, 6, 7, 8")
+	fs.IntVar(&q.page, "page", 1, "page number")
+	fs.IntVar(&q.size, "size", filing.DefaultPageSize, "page size, at most "+strconv.Itoa(filing.MaxPageSize))
+	fs.StringVar(&q.format, "format", formatTable, "output format: table, json, csv or ndjson")
+	fs.DurationVar(&q.timeout, "timeout", 30*time.Second, "timeout of each lookup")
+	fs.StringVar(&q.proxy, "proxy", "", "HTTP proxy URL, such as http://127.0.0.1:8080")
+}
+
+// parse parse args into the flags of fs
+func (q *queryFlags) parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "page" || f.Name == "size" {
+			q.paged = true
+		}
+	})
+	return nil
+}
+
+// client build the filling client from the flags
+func (q *queryFlags) client(ctx context.Context, opts []filing.Option) (*filing.Filling, error) {
+	if !validFormat(q.format) {
+		return nil, fmt.Errorf("unknown format %q", q.format)
+	}
+	if err := filing.NewQuery("").Page(q.page).Size(q.size).Validate(); err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if q.proxy != "" {
+		proxy, err := url.Parse(q.proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	client := &http.Client{Transport: transport, Timeout: q.timeout}
+	return filing.NewWithError(ctx, append([]filing.Option{filing.WithDoer(client)}, opts...)...)
+}
+
+func runQuery(ctx context.Context, args []string, stdout, stderr io.Writer, opts []filing.Option) int {
+	var (
+		fs = flag.NewFlagSet("query", flag.ContinueOnError)
+		qf queryFlags
+	)
+	fs.SetOutput(stderr)
+	qf.register(fs)
+	if err := qf.parse(fs, args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(stderr, "icp query: missing domain or company")
+		return exitUsage
+	}
+	return lookupAll(ctx, &qf, fs.Args(), stdout, stderr, opts)
+}
+
+func runBatch(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer, opts []filing.Option) int {
+	var (
+		fs   = flag.NewFlagSet("batch", flag.ContinueOnError)
+		qf   queryFlags
+		file string
+	)
+	fs.SetOutput(stderr)
+	qf.register(fs)
+	fs.StringVar(&file, "f", "", "file with one domain or company per line, - for stdin")
+	if err := qf.parse(fs, args); err != nil {
+		return exitUsage
+	}
+	if file == "" {
+		fmt.Fprintln(stderr, "icp batch: missing -f file")
+		return exitUsage
+	}
+
+	in := stdin
+	if file != "-" {
+		fh, err := os.Open(file)
+		if err != nil {
+			fmt.Fprintln(stderr, "icp batch:", err)
+			return exitError
+		}
+		defer func() {
+			_ = fh.Close()
+		}()
+		in = fh
+	}
+	var (
+		queries []string
+		scanner = bufio.NewScanner(in)
+	)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			queries = append(queries, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintln(stderr, "icp batch:", err)
+		return exitError
+	}
+	return lookupAll(ctx, &qf, queries, stdout, stderr, opts)
+}
+
+// lookupAll look up every query and write the results
+func lookupAll(ctx context.Context, qf *queryFlags, queries []string, stdout, stderr io.Writer, opts []filing.Option) int {
+	f, err := qf.client(ctx, opts)
+	if err != nil {
+		fmt.Fprintln(stderr, "icp:", err)
+		return exitUsage
+	}
+	var (
+		code    = exitFiled
+		results = make([]*result, 0, len(queries))
+	)
+	for _, q := range queries {
+		r := lookup(ctx, f, qf, q)
+		switch {
+		case r.Error != "":
+			fmt.Fprintf(stderr, "icp: %s: %s\n", q, r.Error)
+			code = exitError
+		case !r.Filed && code == exitFiled:
+			code = exitNotFiled
+		}
+		results = append(results, r)
+	}
+	if err = write(stdout, qf.format, results); err != nil {
+		fmt.Fprintln(stderr, "icp:", err)
+		return exitError
+	}
+	return code
+}
+
+// lookup query one domain or company. A website domain without -page or
+// -size is checked with filing.Check, any other domain query is sent for the
+// registrable domain and only matches its own records.
+func lookup(ctx context.Context, f *filing.Filling, qf *queryFlags, query string) *result {
+	ctx, cancel := context.WithTimeout(ctx, qf.timeout)
+	defer cancel()
+
+	r := &result{Query: query}
+	if qf.serviceType == filing.ServiceWebsite && !qf.paged {
+		v, err := f.Check(ctx, query)
+		switch {
+		case err == nil:
+			r.Filed = v.Filed
+			if v.Record != nil {
+				r.Records = []*filing.DomainInfo{v.Record}
+			}
+			return r
+		case !errors.Is(err, filing.ErrInvalidDomain):
+			r.Error = err.Error()
+			return r
+		}
+	}
+
+	var (
+		req    = filing.NewQuery(query).Type(qf.serviceType).Page(qf.page).Size(qf.size)
+		domain string
+	)
+	if resp, err := f.DomainTLD(ctx, tld.Hostname(query), 0); err == nil && resp.Domain != "" {
+		domain = resp.Domain
+		req.UnitName = domain
+	}
+	resp, err := f.DomainFilling(ctx, req)
+	if err == nil && resp == nil {
+		err = errors.New("response is nil")
+	}
+	if err == nil && !resp.Success {
+		err = errors.New("code: " + strconv.Itoa(resp.Code) + " errMsg: " + resp.Msg)
+	}
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	if resp.Params != nil {
+		for _, info := range resp.Params.List {
+			if domain == "" || strings.EqualFold(strings.TrimSpace(info.Domain), domain) {
+				r.Records = append(r.Records, info)
+			}
+		}
+	}
+	r.Filed = len(r.Records) > 0
+	return r
+}
+
+func runTLD(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("tld", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	level := fs.Int("level", 0, "number of subdomain labels to keep")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(stderr, "icp tld: missing url")
+		return exitUsage
+	}
+	code := exitOK
+	for _, link := range fs.Args() {
+		resp, err := tld.GetTLD(ctx, tld.Hostname(link), *level)
+		if err != nil {
+			fmt.Fprintf(stderr, "icp tld: %s: %v\n", link, err)
+			code = exitError
+			continue
+		}
+		fmt.Fprintf(stdout, "%s\t%s\t%s\t%s\n", link, resp.Domain, resp.SubDomain, resp.Tld)
+	}
+	return code
+}
@@ -0,0 +1,87 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	filing "github.com/houseme/icp-filing"
+	"github.com/houseme/icp-filing/utility/request"
+)
+
+// stubDoer answers baidu.com as filed, example.com as not filed and fails on broken.com
+var stubDoer = request.DoerFunc(func(req *http.Request) (*http.Response, error) {
+	body := `{"code":200,"success":true,"params":{"bussiness":"token"}}`
+	if strings.HasSuffix(req.URL.Path, "queryByCondition") {
+		var in filing.QueryRequest
+		_ = json.NewDecoder(req.Body).Decode(&in)
+		switch in.UnitName {
+		case "baidu.com":
+			body = `{"code":200,"success":true,"params":{"list":[{"domain":"baidu.com","unitName":"北京百度网讯科技有限公司","natureName":"企业","mainLicence":"京ICP证030173号","serviceLicence":"京ICP证030173号-1","updateRecordTime":"2023-06-01 10:00:00"}],"total":1}}`
+		case "broken.com":
+			body = `{"code":500,"msg":"upstream failure","success":false}`
+		default:
+			body = `{"code":200,"success":true,"params":{"list":[{"domain":"other.com"}],"total":1}}`
+		}
+	}
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+})
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		wantCode int
+		wantOut  []string
+	}{
+		{name: "usage", args: nil, wantCode: exitUsage},
+		{name: "unknown command", args: []string{"nope"}, wantCode: exitUsage},
+		{name: "unknown format", args: []string{"query", "-format", "xml", "baidu.com"}, wantCode: exitUsage},
+		{name: "page size too large", args: []string{"query", "-size", "100", "baidu.com"}, wantCode: exitUsage},
+		{name: "filed url", args: []string{"query", "https://www.baidu.com/s?wd=icp"}, wantCode: exitFiled, wantOut: []string{"北京百度网讯科技有限公司", "京ICP证030173号-1"}},
+		{name: "app url", args: []string{"query", "-type", "app", "https://www.baidu.com/"}, wantCode: exitFiled, wantOut: []string{"京ICP证030173号-1"}},
+		{name: "paged domain", args: []string{"query", "-page", "2", "example.com"}, wantCode: exitNotFiled},
+		{name: "not filed", args: []string{"query", "-format", "json", "example.com"}, wantCode: exitNotFiled, wantOut: []string{`"filed": false`}},
+		{name: "error", args: []string{"query", "-format", "ndjson", "baidu.com", "broken.com"}, wantCode: exitError, wantOut: []string{`"filed":true`, `"error":"code: 500 errMsg: upstream failure"`}},
+		{name: "batch csv", args: []string{"batch", "-format", "csv", "-f", "-"}, stdin: "# domains\nbaidu.com\n\nexample.com\n", wantCode: exitNotFiled,
+			wantOut: []string{"query,filed,domain", "baidu.com,true,baidu.com", "example.com,false,"}},
+		{name: "tld", args: []string{"tld", "https://a.b.example.com.cn/path"}, wantCode: exitOK, wantOut: []string{"example.com.cn\t"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(context.Background(), tt.args, strings.NewReader(tt.stdin), &stdout, &stderr, filing.WithDoer(stubDoer))
+			if code != tt.wantCode {
+				t.Errorf("run() = %d, want %d, stderr: %s", code, tt.wantCode, stderr.String())
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("run() stdout lacks %q:\n%s", want, stdout.String())
+				}
+			}
+		})
+	}
+}
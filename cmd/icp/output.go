@@ -0,0 +1,126 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	filing "github.com/houseme/icp-filing"
+)
+
+// output formats
+const (
+	formatTable  = "table"
+	formatJSON   = "json"
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+// columns are the header of the table and CSV formats
+var columns = []string{"query", "filed", "domain", "unit", "nature", "main_licence", "service_licence", "updated", "error"}
+
+// result is the outcome of one lookup
+type result struct {
+	Query   string               `json:"query"`
+	Filed   bool                 `json:"filed"`
+	Records []*filing.DomainInfo `json:"records,omitempty"`
+	Error   string               `json:"error,omitempty"`
+}
+
+// rows flatten r into one row per record, or a single row without records
+func (r *result) rows() [][]string {
+	filed := strconv.FormatBool(r.Filed)
+	if len(r.Records) == 0 {
+		return [][]string{{r.Query, filed, "", "", "", "", "", "", r.Error}}
+	}
+	rows := make([][]string, 0, len(r.Records))
+	for _, info := range r.Records {
+		rows = append(rows, []string{r.Query, filed, info.Domain, info.UnitName, info.NatureName,
+			info.MainLicence, info.ServiceLicence, info.UpdateRecordTime, r.Error})
+	}
+	return rows
+}
+
+// validFormat reports whether format is supported
+func validFormat(format string) bool {
+	switch format {
+	case formatTable, formatJSON, formatCSV, formatNDJSON:
+		return true
+	}
+	return false
+}
+
+// write the results to w in format
+func write(w io.Writer, format string, results []*result) error {
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	case formatNDJSON:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		for _, r := range results {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(columns); err != nil {
+			return err
+		}
+		for _, r := range results {
+			if err := cw.WriteAll(r.rows()); err != nil {
+				return err
+			}
+		}
+		return cw.Error()
+	case formatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for i, column := range columns {
+			if i > 0 {
+				fmt.Fprint(tw, "\t")
+			}
+			fmt.Fprint(tw, column)
+		}
+		fmt.Fprintln(tw)
+		for _, r := range results {
+			for _, row := range r.rows() {
+				for i, cell := range row {
+					if i > 0 {
+						fmt.Fprint(tw, "\t")
+					}
+					fmt.Fprint(tw, cell)
+				}
+				fmt.Fprintln(tw)
+			}
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
@@ -20,21 +20,22 @@ package filling
 
 import (
 	"encoding/json"
+	"log/slog"
 	"strconv"
 )
 
 // QueryRequest query request
 type QueryRequest struct {
-	PageNum     string `json:"pageNum"`
-	PageSize    string `json:"pageSize"`
-	UnitName    string `json:"unitName" description:"unit name"`
-	Link        string `json:"link,omitempty" description:"link"`
-	ServiceType int    `json:"serviceType" description:"serviceType：1 网站，6 APP，7 小程序，8 快应用"`
+	PageNum     int         `json:"pageNum,string"`
+	PageSize    int         `json:"pageSize,string"`
+	UnitName    string      `json:"unitName" description:"unit name"`
+	Link        string      `json:"link,omitempty" description:"link"`
+	ServiceType ServiceType `json:"serviceType" description:"serviceType：1 网站，6 APP，7 小程序，8 快应用"`
 }
 
 // String return query request string
 func (r *QueryRequest) String() string {
-	return `{"pageNum": "` + r.PageNum + `", "pageSize": "` + r.PageSize + `", "unitName": "` + r.UnitName + `","link": "` + r.Link + `", "serviceType": ` + strconv.Itoa(r.ServiceType) + `}`
+	return toJSON(r)
 }
 
 // QueryResponse query response
@@ -45,9 +46,42 @@ type QueryResponse struct {
 	Params  *QueryParams `json:"params"`
 }
 
-// String return query response string
+// String return query response string, leader names are redacted
 func (r *QueryResponse) String() string {
-	return `{"code": ` + strconv.Itoa(r.Code) + `, "msg": "` + r.Msg + `", "success": ` + strconv.FormatBool(r.Success) + `, "params": ` + r.Params.String() + `}`
+	if r == nil {
+		return "null"
+	}
+	c := *r
+	c.Params = r.Params.redacted()
+	return toJSON(&c)
+}
+
+// LogValue implements slog.LogValuer, leader names are redacted
+func (r *QueryResponse) LogValue() slog.Value {
+	if r == nil {
+		return slog.AnyValue(nil)
+	}
+	return slog.GroupValue(
+		slog.Int("code", r.Code),
+		slog.String("msg", r.Msg),
+		slog.Bool("success", r.Success),
+		slog.Any("params", r.Params),
+	)
+}
+
+// clone return a copy of r that shares the records but not the list, so the
+// copy and r can be changed independently
+func (r *QueryResponse) clone() *QueryResponse {
+	if r == nil {
+		return nil
+	}
+	c := *r
+	if r.Params != nil {
+		params := *r.Params
+		params.List = append([]*DomainInfo(nil), r.Params.List...)
+		c.Params = &params
+	}
+	return &c
 }
 
 // AuthParams auth params
@@ -79,33 +113,60 @@ type QueryParams struct {
 	Total            int           `json:"total"`
 }
 
-// String return query params string
+// String return query params string, leader names are redacted
 func (r *QueryParams) String() string {
-	return `{"endRow": ` + strconv.Itoa(r.EndRow) + `, "firstPage": ` + strconv.Itoa(r.FirstPage) + `, "hasNextPage": ` + strconv.FormatBool(r.HasNextPage) + `, "hasPreviousPage": ` + strconv.FormatBool(r.HasPreviousPage) + `, "isFirstPage": ` + strconv.FormatBool(r.IsFirstPage) + `, "isLastPage": ` + strconv.FormatBool(r.IsLastPage) + `, "lastPage": ` + strconv.Itoa(r.LastPage) + `, "list": ` + r.ParamsListString() + `, "navigatePages": ` + strconv.Itoa(r.NavigatePages) + `, "navigatepageNums": ` + r.NavigatePageNumsString() + `, "nextPage": ` + strconv.Itoa(r.NextPage) + `, "pageNum": ` + strconv.Itoa(r.PageNum) + `, "pageSize": ` + strconv.Itoa(r.PageSize) + `, "pages": ` + strconv.Itoa(r.Pages) + `, "prePage": ` + strconv.Itoa(r.PrePage) + `, "size": ` + strconv.Itoa(r.Size) + `, "startRow": ` + strconv.Itoa(r.StartRow) + `, "total": ` + strconv.Itoa(r.Total) + `}`
+	return toJSON(r.redacted())
 }
 
-// NavigatePageNumsString Navigate Page Nums String
-func (r *QueryParams) NavigatePageNumsString() string {
-	if r.NavigatePageNums == nil || len(r.NavigatePageNums) < 1 {
-		return ""
+// LogValue implements slog.LogValuer, the records are logged as a group keyed
+// by their index and their leader names are redacted
+func (r *QueryParams) LogValue() slog.Value {
+	if r == nil {
+		return slog.AnyValue(nil)
 	}
-	output, err := json.Marshal(r.NavigatePageNums)
-	if err != nil {
-		return ""
+	list := make([]slog.Attr, len(r.List))
+	for i, info := range r.List {
+		list[i] = slog.Any(strconv.Itoa(i), info)
 	}
-	return string(output)
+	return slog.GroupValue(
+		slog.Int("pageNum", r.PageNum),
+		slog.Int("pageSize", r.PageSize),
+		slog.Int("pages", r.Pages),
+		slog.Int("total", r.Total),
+		slog.Bool("hasNextPage", r.HasNextPage),
+		slog.Attr{Key: "list", Value: slog.GroupValue(list...)},
+	)
 }
 
-// ParamsListString return params list to string
-func (r *QueryParams) ParamsListString() string {
-	if r.List == nil || len(r.List) < 1 {
-		return ""
+// redacted return a copy of r with the leader names redacted
+func (r *QueryParams) redacted() *QueryParams {
+	if r == nil {
+		return nil
 	}
-	output, err := json.Marshal(r.List)
-	if err != nil {
-		return ""
+	c := *r
+	if r.List != nil {
+		c.List = make([]*DomainInfo, len(r.List))
+		for i, info := range r.List {
+			c.List[i] = info.redacted()
+		}
 	}
-	return string(output)
+	return &c
+}
+
+// NavigatePageNumsString return the navigate page numbers as JSON, [] when empty
+func (r *QueryParams) NavigatePageNumsString() string {
+	if r == nil || len(r.NavigatePageNums) == 0 {
+		return "[]"
+	}
+	return toJSON(r.NavigatePageNums)
+}
+
+// ParamsListString return params list as JSON, [] when empty, leader names are redacted
+func (r *QueryParams) ParamsListString() string {
+	if r == nil || len(r.List) == 0 {
+		return "[]"
+	}
+	return toJSON(r.redacted().List)
 }
 
 // DomainInfo domain info
@@ -126,9 +187,42 @@ type DomainInfo struct {
 	UpdateRecordTime string `json:"updateRecordTime"`
 }
 
-// String return domain info string
+// String return domain info string, the leader name is redacted
 func (r *DomainInfo) String() string {
-	return `{"contentTypeName": "` + r.ContentTypeName + `", "domain": "` + r.Domain + `", "domainId": ` + strconv.FormatInt(r.DomainID, 10) + `, "homeUrl": "` + r.HomeURL + `", "leaderName": "` + r.LeaderName + `", "limitAccess": "` + r.LimitAccess + `", "mainId": ` + strconv.FormatInt(r.MainID, 10) + `, "mainLicence": "` + r.MainLicence + `", "natureName": "` + r.NatureName + `", "serviceId": ` + strconv.FormatInt(r.ServiceID, 10) + `, "serviceLicence": "` + r.ServiceLicence + `", "serviceName": "` + r.ServiceName + `", "unitName": "` + r.UnitName + `", "updateRecordTime": "` + r.UpdateRecordTime + `}`
+	return toJSON(r.redacted())
+}
+
+// redacted return a copy of r with the leader name redacted
+func (r *DomainInfo) redacted() *DomainInfo {
+	if r == nil {
+		return nil
+	}
+	c := *r
+	c.LeaderName = redact(r.LeaderName)
+	return &c
+}
+
+// LogValue implements slog.LogValuer, the leader name is redacted
+func (r *DomainInfo) LogValue() slog.Value {
+	if r == nil {
+		return slog.AnyValue(nil)
+	}
+	return slog.GroupValue(
+		slog.String("contentTypeName", r.ContentTypeName),
+		slog.String("domain", r.Domain),
+		slog.Int64("domainId", r.DomainID),
+		slog.String("homeUrl", r.HomeURL),
+		slog.String("leaderName", redact(r.LeaderName)),
+		slog.String("limitAccess", r.LimitAccess),
+		slog.Int64("mainId", r.MainID),
+		slog.String("mainLicence", r.MainLicence),
+		slog.String("natureName", r.NatureName),
+		slog.Int64("serviceId", r.ServiceID),
+		slog.String("serviceLicence", r.ServiceLicence),
+		slog.String("serviceName", r.ServiceName),
+		slog.String("unitName", r.UnitName),
+		slog.String("updateRecordTime", r.UpdateRecordTime),
+	)
 }
 
 // AuthorizeRequest authorize request
@@ -137,9 +231,20 @@ type AuthorizeRequest struct {
 	Timestamp string `json:"timeStamp"`
 }
 
-// String return authorizes request string
+// String return authorizes request string, the auth key is redacted
 func (r *AuthorizeRequest) String() string {
-	return `{"authKey": "` + r.AuthKey + `", "timeStamp": "` + r.Timestamp + `"}`
+	if r == nil {
+		return "null"
+	}
+	return toJSON(&AuthorizeRequest{AuthKey: redact(r.AuthKey), Timestamp: r.Timestamp})
+}
+
+// LogValue implements slog.LogValuer, the auth key is redacted
+func (r *AuthorizeRequest) LogValue() slog.Value {
+	if r == nil {
+		return slog.AnyValue(nil)
+	}
+	return slog.GroupValue(slog.String("authKey", redact(r.AuthKey)), slog.String("timeStamp", r.Timestamp))
 }
 
 // AuthorizeResponse authorize response
@@ -168,12 +273,17 @@ type ParamInput struct {
 
 // String request params string
 func (r *ParamInput) String() string {
-	if r.QueryRequest == nil {
-		return `{"authorizeRequest": ` + r.AuthorizeRequest.String() + `, "path": "` + r.Path + `", "contentType": "` + r.ContentType + `"}`
+	if r == nil {
+		return "null"
 	}
-	if r.AuthorizeRequest == nil {
-		return `{"queryRequest": ` + r.QueryRequest.String() + `, "path": "` + r.Path + `", "contentType": "` + r.ContentType + `"}`
+	var auth json.RawMessage
+	if r.AuthorizeRequest != nil {
+		auth = json.RawMessage(r.AuthorizeRequest.String())
 	}
-
-	return `{"authorizeRequest": ` + r.AuthorizeRequest.String() + `, "queryRequest": ` + r.QueryRequest.String() + `, "path": "` + r.Path + `", "contentType": "` + r.ContentType + `"}`
+	return toJSON(struct {
+		AuthorizeRequest json.RawMessage `json:"authorizeRequest,omitempty"`
+		QueryRequest     *QueryRequest   `json:"queryRequest,omitempty"`
+		Path             string          `json:"path"`
+		ContentType      string          `json:"contentType"`
+	}{auth, r.QueryRequest, r.Path, r.ContentType})
 }
@@ -0,0 +1,76 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestString_ValidJSON(t *testing.T) {
+	info := &DomainInfo{Domain: `baidu.com"`, UnitName: "北京\\百度\n", UpdateRecordTime: "2023-06-01 10:00:00"}
+	tests := map[string]string{
+		"QueryRequest":         NewQuery(`a"b`).String(),
+		"QueryResponse":        (&QueryResponse{Code: 200, Msg: "<ok>"}).String(),
+		"QueryResponse nil":    (*QueryResponse)(nil).String(),
+		"QueryParams empty":    (&QueryParams{}).String(),
+		"QueryParams":          (&QueryParams{List: []*DomainInfo{info}, NavigatePageNums: []int{1, 2}}).String(),
+		"ParamsListString":     (&QueryParams{}).ParamsListString(),
+		"NavigatePageNums":     (&QueryParams{}).NavigatePageNumsString(),
+		"DomainInfo":           info.String(),
+		"AuthorizeRequest":     (&AuthorizeRequest{AuthKey: "k", Timestamp: `1"`}).String(),
+		"ParamInput":           (&ParamInput{QueryRequest: NewQuery("x"), Path: queryPath}).String(),
+		"ParamInput authorize": (&ParamInput{AuthorizeRequest: &AuthorizeRequest{AuthKey: "k"}, Path: authorizePath}).String(),
+		"Filling":              (&Filling{ip: `1"`, token: "t"}).String(),
+	}
+	for name, s := range tests {
+		if !json.Valid([]byte(s)) {
+			t.Errorf("%s.String() = %s, not valid JSON", name, s)
+		}
+	}
+	var back DomainInfo
+	if err := json.Unmarshal([]byte(info.String()), &back); err != nil || back != *info {
+		t.Errorf("DomainInfo.String() round trip = %+v, %v", back, err)
+	}
+}
+
+func FuzzDomainInfo_String(f *testing.F) {
+	f.Add("baidu.com", "北京百度网讯科技有限公司", "京ICP证030173号-1", "2023-06-01 10:00:00", int64(1))
+	f.Add(`"}{`, "\\", "\x00\n\t", "<script>", int64(-1))
+	f.Add("\xff\xfe", "", " ", "", int64(0))
+	f.Fuzz(func(t *testing.T, domain, unit, licence, updated string, id int64) {
+		info := &DomainInfo{Domain: domain, UnitName: unit, ServiceLicence: licence, UpdateRecordTime: updated, DomainID: id, LeaderName: unit}
+		s := info.String()
+		if !json.Valid([]byte(s)) {
+			t.Fatalf("DomainInfo.String() = %q, not valid JSON", s)
+		}
+		resp := &QueryResponse{Msg: licence, Params: &QueryParams{List: []*DomainInfo{info}}}
+		if s = resp.String(); !json.Valid([]byte(s)) {
+			t.Fatalf("QueryResponse.String() = %q, not valid JSON", s)
+		}
+		in := &ParamInput{AuthorizeRequest: &AuthorizeRequest{AuthKey: domain, Timestamp: updated}, QueryRequest: NewQuery(unit), Path: licence}
+		if s = in.String(); !json.Valid([]byte(s)) {
+			t.Fatalf("ParamInput.String() = %q, not valid JSON", s)
+		}
+		if domain != "" && domain != redacted && strings.Contains(s, `"authKey":"`+domain) {
+			t.Fatalf("ParamInput.String() = %q leaks the auth key", s)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+)
+
+// Entity is the profile of a filing entity, a company or another unit
+type Entity struct {
+	UnitName     string        `json:"unitName"`
+	NatureName   string        `json:"natureName"`
+	MainLicence  string        `json:"mainLicence"`
+	LeaderName   string        `json:"leaderName"`
+	Websites     []*DomainInfo `json:"websites"`
+	Apps         []*DomainInfo `json:"apps"`
+	MiniPrograms []*DomainInfo `json:"miniPrograms"`
+	QuickApps    []*DomainInfo `json:"quickApps"`
+}
+
+// Filed reports whether the entity has a filed service
+func (e *Entity) Filed() bool {
+	return len(e.Websites)+len(e.Apps)+len(e.MiniPrograms)+len(e.QuickApps) > 0
+}
+
+// LogValue implements slog.LogValuer, the leader name is redacted
+func (e *Entity) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("unitName", e.UnitName),
+		slog.String("natureName", e.NatureName),
+		slog.String("mainLicence", e.MainLicence),
+		slog.String("leaderName", redact(e.LeaderName)),
+		slog.Int("websites", len(e.Websites)),
+		slog.Int("apps", len(e.Apps)),
+		slog.Int("miniPrograms", len(e.MiniPrograms)),
+		slog.Int("quickApps", len(e.QuickApps)),
+	)
+}
+
+// QueryEntity return the profile of company with its services of every
+// service type, read across every page. The upstream matches names loosely,
+// only the records of exactly company are kept; an entity without records
+// is not filed.
+func (i *Filling) QueryEntity(ctx context.Context, company string) (*Entity, error) {
+	company = strings.TrimSpace(company)
+	if company == "" {
+		return nil, errors.New("company is empty")
+	}
+	e := &Entity{UnitName: company}
+	for _, group := range []struct {
+		serviceType ServiceType
+		records     *[]*DomainInfo
+	}{
+		{ServiceWebsite, &e.Websites},
+		{ServiceApp, &e.Apps},
+		{ServiceMiniProgram, &e.MiniPrograms},
+		{ServiceQuickApp, &e.QuickApps},
+	} {
+		records, err := i.queryAll(ctx, NewQuery(company).Type(group.serviceType))
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range records {
+			if info.UnitName != company {
+				continue
+			}
+			*group.records = append(*group.records, info)
+			if e.MainLicence == "" {
+				e.NatureName, e.MainLicence, e.LeaderName = info.NatureName, info.MainLicence, info.LeaderName
+			}
+		}
+	}
+	return e, nil
+}
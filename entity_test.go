@@ -0,0 +1,68 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestFilling_QueryEntity(t *testing.T) {
+	const company = "北京百度网讯科技有限公司"
+	ctx := context.Background()
+	f := New(ctx, WithDoer(recordsDoer(func(in QueryRequest) []*DomainInfo {
+		switch in.ServiceType {
+		case ServiceWebsite:
+			return []*DomainInfo{
+				{Domain: "baidu.com", UnitName: company, NatureName: "企业", MainLicence: "京ICP证030173号", LeaderName: "张三"},
+				{Domain: "baidu.cn", UnitName: company, NatureName: "企业", MainLicence: "京ICP证030173号", LeaderName: "张三"},
+				{Domain: "baidu-fan.com", UnitName: company + "分公司"},
+			}
+		case ServiceApp:
+			return []*DomainInfo{{ServiceName: "百度", UnitName: company}}
+		case ServiceMiniProgram:
+			return []*DomainInfo{{ServiceName: "百度小程序", UnitName: company}}
+		}
+		return nil
+	})))
+
+	e, err := f.QueryEntity(ctx, " "+company+" ")
+	if err != nil {
+		t.Fatalf("QueryEntity() error = %v", err)
+	}
+	if e.UnitName != company || e.NatureName != "企业" || e.MainLicence != "京ICP证030173号" || e.LeaderName != "张三" {
+		t.Errorf("QueryEntity() profile = %+v", e)
+	}
+	if len(e.Websites) != 2 || len(e.Apps) != 1 || len(e.MiniPrograms) != 1 || len(e.QuickApps) != 0 || !e.Filed() {
+		t.Errorf("QueryEntity() = %d websites, %d apps, %d mini programs, %d quick apps",
+			len(e.Websites), len(e.Apps), len(e.MiniPrograms), len(e.QuickApps))
+	}
+
+	var buf strings.Builder
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("entity", "entity", e)
+	if strings.Contains(buf.String(), "张三") {
+		t.Errorf("log leaks the leader name: %s", buf.String())
+	}
+
+	if _, err = f.QueryEntity(ctx, " "); err == nil {
+		t.Errorf("QueryEntity() of an empty company error = nil")
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	filing "github.com/houseme/icp-filing"
+	"github.com/houseme/icp-filing/monitor"
+	"github.com/houseme/icp-filing/utility/request"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	f := filing.New(ctx, filing.WithDoer(request.DefaultClient()), filing.WithRateLimiter(rate.NewLimiter(1, 1)))
+	events := make(chan monitor.Event)
+	m := monitor.New(f, monitor.WithChannel(events))
+	for _, domain := range os.Args[1:] {
+		if _, err := m.Add(domain, 24*time.Hour, nil); err != nil {
+			panic(err)
+		}
+	}
+	go func() {
+		for e := range events {
+			switch e.Type {
+			case monitor.CheckFailed:
+				fmt.Println(e.Domain, e.Type, e.Err)
+			default:
+				fmt.Println(e.Domain, e.Type, e.Change)
+			}
+		}
+	}()
+
+	_ = m.Run(ctx)
+}
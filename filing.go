@@ -20,19 +20,24 @@
 package filling
 
 import (
+	"bytes"
 	"context"
 	"crypto/md5"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"math/rand"
+	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
-	"github.com/houseme/icp-filing/tld"
 	"github.com/houseme/icp-filing/utility/logger"
 	"github.com/houseme/icp-filing/utility/request"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -42,6 +47,7 @@ const (
 	authorizeContentType = "application/x-www-form-urlencoded;charset=UTF-8"
 	queryContentType     = "application/json;charset=UTF-8"
 
+	apiBaseURL  = "https://hlwicpfwc.miit.gov.cn/icpproject_query/api/"
 	httpOrigin  = "https://beian.miit.gov.cn"
 	httpReferer = "https://beian.miit.gov.cn/"
 
@@ -56,51 +62,227 @@ const (
 
 // Filling is the icp filling number object
 type Filling struct {
-	token   string
-	ip      string
-	request request.Request
-	logger  logger.ILogger
+	mu              sync.RWMutex // guards token, tokenExpires and solved
+	authMu          sync.Mutex   // serializes authorize calls
+	token           string
+	tokenExpires    time.Time
+	ip              string
+	doer            request.Doer
+	logger          *slog.Logger
+	handler         Handler
+	stream          bool // no middleware needs the raw body, see send
+	solver          ChallengeSolver
+	solved          map[string]string // headers returned by the challenge solver
+	maxResponseSize int64
+	tracer          trace.Tracer
+	metrics         Metrics
+	cache           Cache
+	limiter         RateLimiter
 }
 
 type options struct {
-	Request request.Request
-	Logger  logger.ILogger
+	Request         request.Request
+	Doer            request.Doer
+	Logger          logger.ILogger
+	Slog            *slog.Logger
+	Middlewares     []Middleware
+	ChallengeSolver ChallengeSolver
+	MaxResponseSize int64
+	TracerProvider  trace.TracerProvider
+	Metrics         Metrics
+	Cache           Cache
+	RateLimiter     RateLimiter
 }
 
 // Option is the option for logger.
 type Option func(o *options)
 
-// WithRequest is the option for request.
+// WithRequest is the option for request, it is adapted with request.AsDoer.
 func WithRequest(req request.Request) Option {
 	return func(o *options) {
 		o.Request = req
 	}
 }
 
-// WithLogger is the option for logger.
+// WithDoer is the option for the HTTP client, *http.Client satisfies request.Doer.
+func WithDoer(doer request.Doer) Option {
+	return func(o *options) {
+		o.Doer = doer
+	}
+}
+
+// WithLogger is the option for logger, it is adapted with logger.NewHandler.
 func WithLogger(logger logger.ILogger) Option {
 	return func(o *options) {
 		o.Logger = logger
 	}
 }
 
-// New return a new filling number object
+// WithSlog is the option for the structured logger.
+func WithSlog(l *slog.Logger) Option {
+	return func(o *options) {
+		o.Slog = l
+	}
+}
+
+// WithSlogHandler is the option for the structured logger handler.
+func WithSlogHandler(h slog.Handler) Option {
+	return func(o *options) {
+		o.Slog = slog.New(h)
+	}
+}
+
+// WithMaxResponseSize is the option for the upstream response body limit,
+// request.DefaultMaxResponseSize is used by default.
+func WithMaxResponseSize(size int64) Option {
+	return func(o *options) {
+		o.MaxResponseSize = size
+	}
+}
+
+// ErrInvalidOption is returned by NewWithError when the options conflict
+var ErrInvalidOption = errors.New("invalid option")
+
+// validate check that the options do not conflict
+func (o *options) validate() error {
+	if o.Request != nil && o.Doer != nil {
+		return fmt.Errorf("%w: WithRequest and WithDoer are mutually exclusive", ErrInvalidOption)
+	}
+	if o.Logger != nil && o.Slog != nil {
+		return fmt.Errorf("%w: WithLogger and WithSlog are mutually exclusive", ErrInvalidOption)
+	}
+	if o.MaxResponseSize < 0 {
+		return fmt.Errorf("%w: negative max response size %d", ErrInvalidOption, o.MaxResponseSize)
+	}
+	return nil
+}
+
+// New return a new filling number object. Without options it logs nothing
+// and uses request.DefaultClient, conflicting options are not reported, the
+// Doer and the slog logger win, use NewWithError to validate them.
 func New(ctx context.Context, opts ...Option) *Filling {
-	var op = options{}
+	return newFilling(ctx, newOptions(opts))
+}
+
+// NewWithError return a new filling number object, or an error wrapping
+// ErrInvalidOption when the options conflict.
+func NewWithError(ctx context.Context, opts ...Option) (*Filling, error) {
+	op := newOptions(opts)
+	if err := op.validate(); err != nil {
+		return nil, err
+	}
+	return newFilling(ctx, op), nil
+}
+
+// newOptions apply opts
+func newOptions(opts []Option) *options {
+	var op = &options{}
 	for _, opt := range opts {
-		opt(&op)
+		if opt != nil {
+			opt(op)
+		}
+	}
+	return op
+}
+
+// newFilling build the filling number object, filling the defaults
+func newFilling(_ context.Context, op *options) *Filling {
+	if op.Doer == nil {
+		op.Doer = request.DefaultClient()
+		if op.Request != nil {
+			op.Doer = request.AsDoer(op.Request)
+		}
+	}
+	if op.Slog == nil {
+		op.Slog = slog.New(logger.DiscardHandler)
+		if op.Logger != nil {
+			op.Slog = slog.New(logger.NewHandler(op.Logger))
+		}
 	}
+	op.Slog = slog.New(newRedactHandler(op.Slog.Handler()))
 	f := &Filling{
-		token:   defaultToken,
-		ip:      fmt.Sprintf(randomIP, rand.Intn(maxValue), rand.Intn(maxValue), rand.Intn(maxValue)),
-		logger:  op.Logger,
-		request: op.Request,
+		token:           defaultToken,
+		ip:              fmt.Sprintf(randomIP, rand.Intn(maxValue), rand.Intn(maxValue), rand.Intn(maxValue)),
+		logger:          op.Slog,
+		doer:            op.Doer,
+		solver:          op.ChallengeSolver,
+		solved:          make(map[string]string),
+		maxResponseSize: op.MaxResponseSize,
+		tracer:          newTracer(op.TracerProvider),
+		metrics:         op.Metrics,
+		cache:           op.Cache,
+		limiter:         op.RateLimiter,
+	}
+	if f.metrics == nil {
+		f.metrics = nopMetrics{}
+	}
+	f.handler = chain(f.send, op.Middlewares)
+	f.stream = true
+	for _, mw := range op.Middlewares {
+		if mw != nil {
+			f.stream = false
+		}
 	}
 	return f
 }
 
-// doRequest execute request
-func (i *Filling) doRequest(ctx context.Context, in *ParamInput) (resp []byte, err error) {
+// doRequest execute request, a challenge is handed to the solver and the call retried once
+func (i *Filling) doRequest(ctx context.Context, in *ParamInput) (*request.Response, error) {
+	resp, c, err := i.call(ctx, in, 0)
+	if c == nil || i.solver == nil {
+		return resp, challengeError(c, err)
+	}
+
+	headers, err := i.solver.Solve(ctx, c)
+	if err != nil {
+		return resp, &ChallengeError{Challenge: c, Err: err}
+	}
+	i.mu.Lock()
+	for key, value := range headers {
+		i.solved[key] = value
+	}
+	i.mu.Unlock()
+	resp, c, err = i.call(ctx, in, 1)
+	return resp, challengeError(c, err)
+}
+
+// call run the handler chain once, logging and measuring the call
+func (i *Filling) call(ctx context.Context, in *ParamInput, retries int) (*request.Response, *Challenge, error) {
+	attrs := []any{slog.String("path", in.Path)}
+	if in.QueryRequest != nil {
+		attrs = append(attrs, slog.String("unitName", in.QueryRequest.UnitName), slog.Int("serviceType", int(in.QueryRequest.ServiceType)))
+	}
+	i.logger.DebugContext(ctx, "upstream request", attrs...)
+
+	if i.limiter != nil {
+		start := time.Now()
+		err := i.limiter.Wait(ctx)
+		i.metrics.ObserveRateLimitWait(time.Since(start))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	start := time.Now()
+	resp, err := i.handler(ctx, in, i.header(in))
+	c := detectChallenge(in, resp)
+	i.metrics.ObserveRequest(in.Path, outcome(c, err), time.Since(start))
+
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode), slog.Duration("duration", resp.Duration))
+		trace.SpanFromContext(ctx).SetAttributes(attrHTTPStatus.Int(resp.StatusCode), attrRetryCount.Int(resp.Attempts-1+retries))
+	}
+	if err != nil {
+		i.logger.WarnContext(ctx, "upstream request failed", append(attrs, slog.Any("error", err))...)
+	} else {
+		i.logger.DebugContext(ctx, "upstream response", attrs...)
+	}
+	return resp, c, err
+}
+
+// header return the headers of an upstream call
+func (i *Filling) header(in *ParamInput) map[string]string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
 	headMap := map[string]string{
 		"Content-Type":    in.ContentType,
 		"Origin":          httpOrigin,
@@ -111,23 +293,65 @@ func (i *Filling) doRequest(ctx context.Context, in *ParamInput) (resp []byte, e
 		"X-FORWARDED-FOR": i.ip,
 		"Sign":            i.token,
 	}
-	i.logger.Debugf(ctx, "do request in params: %s", in.String())
+	for key, value := range i.solved {
+		headMap[key] = value
+	}
+	return headMap
+}
+
+// challengeError wrap err into a ChallengeError when c is not nil
+func challengeError(c *Challenge, err error) error {
+	if c == nil {
+		return err
+	}
+	return &ChallengeError{Challenge: c, Err: err}
+}
+
+// send is the innermost handler, it performs the HTTP call. Without
+// middlewares the JSON answer is decoded while it is read.
+func (i *Filling) send(ctx context.Context, in *ParamInput, headMap map[string]string) (*request.Response, error) {
+	body := new(bytes.Buffer)
 	if in.Path != authorizePath {
-		resp, err = i.request.PostJSON(ctx, "https://hlwicpfwc.miit.gov.cn/icpproject_query/api/"+in.Path, in.QueryRequest, headMap)
+		enc := json.NewEncoder(body)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(in.QueryRequest); err != nil {
+			return nil, err
+		}
 	} else {
 		data := url.Values{}
 		data.Set("authKey", in.AuthorizeRequest.AuthKey)
 		data.Set("timeStamp", in.AuthorizeRequest.Timestamp)
-		encodedData := data.Encode()
-		fmt.Println("encodedData:", encodedData)
-		resp, err = i.request.Post(ctx, "https://hlwicpfwc.miit.gov.cn/icpproject_query/api/"+in.Path, []byte(encodedData), headMap)
+		body.WriteString(data.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiBaseURL+in.Path, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range headMap {
+		if strings.TrimSpace(value) != "" {
+			req.Header.Set(key, value)
+		}
 	}
 
-	return
+	if !i.stream {
+		return request.Send(i.doer, req, i.maxResponseSize)
+	}
+	var out any = new(QueryResponse)
+	if in.Path == authorizePath {
+		out = new(AuthorizeResponse)
+	}
+	return request.Decode(i.doer, req, i.maxResponseSize, out)
 }
 
 // authorize .
-func (i *Filling) authorize(ctx context.Context) error {
+func (i *Filling) authorize(ctx context.Context) (err error) {
+	ctx, span := i.startSpan(ctx, "authorize", attrPath.String(authorizePath))
+	defer func() {
+		i.metrics.IncAuthRefresh(err == nil)
+		endSpan(span, err)
+	}()
+
 	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
 	resp, err := i.doRequest(ctx,
 		&ParamInput{
@@ -142,22 +366,49 @@ func (i *Filling) authorize(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	var response *AuthorizeResponse
-	if err = json.Unmarshal(resp, &response); err != nil {
-		return err
+	response, ok := resp.Value.(*AuthorizeResponse)
+	if !ok {
+		if err = json.Unmarshal(resp.Body, &response); err != nil {
+			return err
+		}
 	}
 	if response == nil {
 		return errors.New("response is nil")
 	}
+	i.logger.DebugContext(ctx, "authorize", slog.Int("code", response.Code), slog.Bool("success", response.Success))
+	span.SetAttributes(attrUpstreamCode.Int(response.Code))
+	i.metrics.IncUpstreamCode(authorizePath, response.Code)
 	if !response.Success {
 		return errors.New("code: " + strconv.Itoa(response.Code) + " errMsg: " + response.Msg)
 	}
+	if response.Params == nil {
+		return errors.New("response params is nil")
+	}
+	i.mu.Lock()
 	i.token = response.Params.Business
+	i.tokenExpires = time.Time{}
+	if response.Params.Expire > 0 {
+		// the token lives Expire milliseconds, it is refreshed a little earlier
+		i.tokenExpires = time.Now().Add(time.Duration(response.Params.Expire) * time.Millisecond * 9 / 10)
+	}
+	i.mu.Unlock()
 	return nil
 }
 
 // QueryFilling query domain filling number
-func (i *Filling) QueryFilling(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
+func (i *Filling) QueryFilling(ctx context.Context, req *QueryRequest) (_ *QueryResponse, err error) {
+	if req == nil {
+		return nil, errors.New("request is nil")
+	}
+	if err = req.Validate(); err != nil {
+		return nil, err
+	}
+	req = req.withDefaults()
+	ctx, span := i.startSpan(ctx, "QueryFilling", append(queryAttributes(req), attrPath.String(queryPath))...)
+	defer func() {
+		endSpan(span, err)
+	}()
+
 	resp, err := i.doRequest(ctx, &ParamInput{
 		QueryRequest:     req,
 		AuthorizeRequest: nil,
@@ -167,9 +418,20 @@ func (i *Filling) QueryFilling(ctx context.Context, req *QueryRequest) (*QueryRe
 	if err != nil {
 		return nil, err
 	}
-	var queryResp *QueryResponse
-	if err = json.Unmarshal(resp, &queryResp); err != nil {
-		return nil, err
+	queryResp, ok := resp.Value.(*QueryResponse)
+	if !ok {
+		if err = json.Unmarshal(resp.Body, &queryResp); err != nil {
+			return nil, err
+		}
+	}
+	if queryResp != nil {
+		span.SetAttributes(attrUpstreamCode.Int(queryResp.Code))
+		i.metrics.IncUpstreamCode(queryPath, queryResp.Code)
+		if queryResp.Code == tokenExpiredCode {
+			i.metrics.IncTokenExpired()
+		}
+		i.logger.DebugContext(ctx, "query filling", slog.String("unitName", req.UnitName), slog.Int("serviceType", int(req.ServiceType)),
+			slog.Int("code", queryResp.Code), slog.Duration("duration", resp.Duration))
 	}
 	return queryResp, nil
 }
@@ -179,29 +441,92 @@ func (i *Filling) md5(str string) string {
 	return fmt.Sprintf("%x", md5.Sum([]byte(str))) // 将 []byte 转成 16 进制
 }
 
-// String return filling JSON string
+// String return filling JSON string, the token is redacted
 func (i *Filling) String() string {
-	return `{"ip":"` + i.ip + `","token":"` + i.token + `"}`
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return toJSON(struct {
+		IP    string `json:"ip"`
+		Token string `json:"token"`
+	}{i.ip, redact(i.token)})
 }
 
 // DomainFilling query domain filling number
 func (i *Filling) DomainFilling(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
+	resp, _, err := i.domainFilling(ctx, req)
+	return resp, err
+}
+
+// domainFilling query domain filling number and report whether the response is cached
+func (i *Filling) domainFilling(ctx context.Context, req *QueryRequest) (_ *QueryResponse, _ Source, err error) {
 	if req == nil {
-		return nil, errors.New("request is nil")
+		return nil, "", errors.New("request is nil")
 	}
+	ctx, span := i.startSpan(ctx, "DomainFilling", queryAttributes(req)...)
+	defer func() {
+		endSpan(span, err)
+	}()
 
 	if req.UnitName == "" && req.Link != "" {
-		resp, err := tld.GetTLD(ctx, req.Link, domainLevel)
+		resp, err := i.DomainTLD(ctx, req.Link, domainLevel)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
-		i.logger.Debugf(ctx, "GetTld resp: %s", resp.String())
+		i.logger.DebugContext(ctx, "parsed link", slog.String("link", req.Link), slog.String("domain", resp.Domain), slog.String("tld", resp.Tld))
 		req.UnitName = resp.Domain
 	}
 
-	if err := i.authorize(ctx); err != nil {
-		return nil, err
+	if err = req.Validate(); err != nil {
+		return nil, "", err
 	}
+	req = req.withDefaults()
 
-	return i.QueryFilling(ctx, req)
+	key := cacheKey(req)
+	if i.cache != nil {
+		resp, ok := i.cache.Get(ctx, key)
+		i.metrics.ObserveCache(ok)
+		span.SetAttributes(attrCacheHit.Bool(ok))
+		if ok {
+			return resp.clone(), SourceCache, nil
+		}
+	}
+
+	token, err := i.ensureToken(ctx, "")
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := i.QueryFilling(ctx, req)
+	if err == nil && resp != nil && resp.Code == tokenExpiredCode {
+		if _, err = i.ensureToken(ctx, token); err != nil {
+			return nil, "", err
+		}
+		resp, err = i.QueryFilling(ctx, req)
+	}
+	if err == nil && resp != nil && resp.Success && i.cache != nil {
+		i.cache.Set(ctx, key, resp.clone())
+	}
+	return resp, SourceLive, err
+}
+
+// ensureToken authorize unless the current token is still valid and return
+// the token in use. A non empty expired is the token the upstream refused, it
+// is replaced unless a concurrent call already did. A token without expiry is
+// never reused.
+func (i *Filling) ensureToken(ctx context.Context, expired string) (string, error) {
+	i.authMu.Lock()
+	defer i.authMu.Unlock()
+
+	i.mu.RLock()
+	token := i.token
+	valid := token != defaultToken && time.Now().Before(i.tokenExpires)
+	i.mu.RUnlock()
+	if (expired != "" && token != expired) || (valid && expired == "") {
+		return token, nil
+	}
+	if err := i.authorize(ctx); err != nil {
+		return "", err
+	}
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.token, nil
 }
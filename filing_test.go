@@ -19,10 +19,19 @@
 package filling
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"github.com/houseme/icp-filing/utility/logger"
 	"github.com/houseme/icp-filing/utility/request"
@@ -80,7 +89,12 @@ func TestICP_String(t *testing.T) {
 		{
 			name:   "TestICP_String",
 			fields: fields{token: defaultToken, ip: "101,110,123,124"},
-			want:   `{"ip":"101,110,123,124","token":"0"}`,
+			want:   `{"ip":"101,110,123,124","token":"******"}`,
+		},
+		{
+			name:   "TestICP_String_live_token",
+			fields: fields{token: "eyJ0eXAiOiJKV1Qi", ip: "101.110.123.124"},
+			want:   `{"ip":"101.110.123.124","token":"******"}`,
 		},
 	}
 	for _, tt := range tests {
@@ -124,10 +138,8 @@ func TestICP_authorize(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			i := &Filling{
-				token: tt.fields.token,
-				ip:    tt.fields.ip,
-			}
+			i := New(tt.args.ctx)
+			i.token, i.ip = tt.fields.token, tt.fields.ip
 			fmt.Println("icp:", i)
 			if err := i.authorize(tt.args.ctx); (err != nil) != tt.wantErr {
 				t.Errorf("authorize() error = %v, wantErr %v", err, tt.wantErr)
@@ -204,3 +216,269 @@ func TestFilling_DomainFilling(t *testing.T) {
 		})
 	}
 }
+
+// legacyRequest only implements the Post method that the Doer adapter needs
+type legacyRequest struct {
+	request.Request
+	posts []string
+}
+
+func (r *legacyRequest) Post(_ context.Context, url string, data []byte, _ map[string]string) ([]byte, error) {
+	r.posts = append(r.posts, url)
+	if strings.HasSuffix(url, authorizePath) {
+		return []byte(testAuthBody), nil
+	}
+	return []byte(testQueryBody), nil
+}
+
+// embeddedRequest embeds *request.DefaultRequest and overrides its Post
+type embeddedRequest struct {
+	*request.DefaultRequest
+	legacy legacyRequest
+}
+
+func (r *embeddedRequest) Post(ctx context.Context, url string, data []byte, headMap map[string]string) ([]byte, error) {
+	return r.legacy.Post(ctx, url, data, headMap)
+}
+
+func TestFilling_WithDoer(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		legacy   = &legacyRequest{}
+		embedded = &embeddedRequest{DefaultRequest: request.NewDefaultRequest()}
+	)
+	doer := request.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		var out string
+		switch req.URL.String() {
+		case apiBaseURL + authorizePath:
+			if got := req.Header.Get("Content-Type"); got != authorizeContentType {
+				t.Errorf("auth Content-Type = %v, want %v", got, authorizeContentType)
+			}
+			if !strings.Contains(string(body), "authKey=") {
+				t.Errorf("auth body = %s", body)
+			}
+			out = testAuthBody
+		case apiBaseURL + queryPath:
+			var in QueryRequest
+			if err := json.Unmarshal(body, &in); err != nil || in.UnitName != "baidu.com" {
+				t.Errorf("query body = %s, err = %v", body, err)
+			}
+			if got := req.Header.Get("Token"); got != "test-token" {
+				t.Errorf("query Token = %v, want test-token", got)
+			}
+			out = testQueryBody
+		default:
+			t.Errorf("unexpected url %v", req.URL)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(bytes.NewBufferString(out))}, nil
+	})
+
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{name: "doer", opt: WithDoer(doer)},
+		{name: "legacy request", opt: WithRequest(legacy)},
+		{name: "embedded default request", opt: WithRequest(embedded)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(ctx, WithLogger(logger.NewDefaultLogger()), tt.opt)
+			got, err := f.DomainFilling(ctx, &QueryRequest{UnitName: "baidu.com", ServiceType: 1})
+			if err != nil {
+				t.Fatalf("DomainFilling() error = %v", err)
+			}
+			if got.Params == nil || len(got.Params.List) != 1 {
+				t.Errorf("DomainFilling() got = %v", got)
+			}
+		})
+	}
+	want := []string{apiBaseURL + authorizePath, apiBaseURL + queryPath}
+	if !reflect.DeepEqual(legacy.posts, want) {
+		t.Errorf("legacy posts = %v, want %v", legacy.posts, want)
+	}
+	if !reflect.DeepEqual(embedded.legacy.posts, want) {
+		t.Errorf("overridden posts = %v, want %v", embedded.legacy.posts, want)
+	}
+}
+
+func TestFilling_HTTPError(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		page = "<html><body>" + strings.Repeat("访问被拦截", 100) + "</body></html>"
+	)
+	doer := request.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusForbidden,
+			Header:     http.Header{"Content-Type": {"text/html"}, "Retry-After": {"30"}},
+			Body:       io.NopCloser(strings.NewReader(page)),
+		}, nil
+	})
+	f := New(ctx, WithLogger(logger.NewDefaultLogger()), WithDoer(doer))
+
+	_, err := f.DomainFilling(ctx, &QueryRequest{UnitName: "baidu.com", ServiceType: 1})
+	var httpErr *request.HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("DomainFilling() error = %v, want *request.HTTPError", err)
+	}
+	if httpErr.StatusCode != http.StatusForbidden {
+		t.Errorf("StatusCode = %v, want %v", httpErr.StatusCode, http.StatusForbidden)
+	}
+	if len(httpErr.Body) > 512 || !utf8.Valid(httpErr.Body) || !strings.HasPrefix(page, string(httpErr.Body)) {
+		t.Errorf("Body = %q, want a valid truncated prefix", httpErr.Body)
+	}
+	if delay, ok := httpErr.RetryAfter(); !ok || delay != 30*time.Second {
+		t.Errorf("RetryAfter() = %v, %v, want 30s, true", delay, ok)
+	}
+}
+
+func TestFilling_WithMaxResponseSize(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		huge = `{"code":200,"msg":"` + strings.Repeat("x", 4096) + `","success":true}`
+	)
+	tests := []struct {
+		name          string
+		contentLength int64
+	}{
+		{name: "announced", contentLength: int64(len(huge))},
+		{name: "chunked", contentLength: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doer := request.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode:    http.StatusOK,
+					Header:        http.Header{"Content-Type": {"application/json"}},
+					Body:          io.NopCloser(strings.NewReader(huge)),
+					ContentLength: tt.contentLength,
+				}, nil
+			})
+			f := New(ctx, WithLogger(logger.NewDefaultLogger()), WithDoer(doer), WithMaxResponseSize(1024))
+			if _, err := f.DomainFilling(ctx, &QueryRequest{UnitName: "baidu.com"}); !errors.Is(err, request.ErrResponseTooLarge) {
+				t.Errorf("DomainFilling() error = %v, want request.ErrResponseTooLarge", err)
+			}
+		})
+	}
+}
+
+func TestFilling_TrailingData(t *testing.T) {
+	ctx := context.Background()
+	doer := queryDoer(func(*http.Request) (int, string, string) {
+		return http.StatusOK, "application/json", testQueryBody + `{"code":500}`
+	})
+
+	f := New(ctx, WithDoer(doer))
+	if _, err := f.DomainFilling(ctx, &QueryRequest{UnitName: "baidu.com"}); !errors.Is(err, request.ErrTrailingData) {
+		t.Errorf("DomainFilling() error = %v, want request.ErrTrailingData", err)
+	}
+
+	f = New(ctx, WithDoer(doer), WithMiddleware(func(next Handler) Handler { return next }))
+	var syntaxErr *json.SyntaxError
+	if _, err := f.DomainFilling(ctx, &QueryRequest{UnitName: "baidu.com"}); !errors.As(err, &syntaxErr) {
+		t.Errorf("DomainFilling() with middleware error = %v, want *json.SyntaxError", err)
+	}
+}
+
+// recordLogger is an ILogger keeping every formatted line
+type recordLogger struct {
+	logger.ILogger
+	lines []string
+}
+
+func (l *recordLogger) Debugf(_ context.Context, format string, v ...interface{}) {
+	l.lines = append(l.lines, "DEBUG "+fmt.Sprintf(format, v...))
+}
+
+func (l *recordLogger) Infof(_ context.Context, format string, v ...interface{}) {
+	l.lines = append(l.lines, "INFO "+fmt.Sprintf(format, v...))
+}
+
+func (l *recordLogger) Errorf(_ context.Context, format string, v ...interface{}) {
+	l.lines = append(l.lines, "ERROR "+fmt.Sprintf(format, v...))
+}
+
+func TestFilling_WithSlog(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		buf    = new(bytes.Buffer)
+		legacy = &recordLogger{}
+		req    = &QueryRequest{UnitName: "baidu.com", ServiceType: 1}
+	)
+	f := New(ctx, WithSlogHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})), WithMiddleware(stubMiddleware))
+	if _, err := f.DomainFilling(ctx, req); err != nil {
+		t.Fatalf("DomainFilling() error = %v", err)
+	}
+
+	var found bool
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("malformed record %q: %v", line, err)
+		}
+		if record["msg"] == "query filling" {
+			found = true
+			if record["unitName"] != "baidu.com" || record["serviceType"] != float64(1) || record["code"] != float64(200) {
+				t.Errorf("query filling record = %v", record)
+			}
+			if _, ok := record["duration"]; !ok {
+				t.Errorf("query filling record without duration: %v", record)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("no query filling record in %s", buf)
+	}
+
+	f = New(ctx, WithLogger(legacy), WithMiddleware(stubMiddleware))
+	if _, err := f.DomainFilling(ctx, req); err != nil {
+		t.Fatalf("DomainFilling() error = %v", err)
+	}
+	if len(legacy.lines) == 0 || legacy.lines[0] != "DEBUG upstream request path=auth" {
+		t.Errorf("legacy logger lines = %q", legacy.lines)
+	}
+}
+
+func TestNew_Defaults(t *testing.T) {
+	ctx := context.Background()
+	f := New(ctx)
+	if f.doer != request.DefaultClient() {
+		t.Errorf("New() doer = %v, want request.DefaultClient()", f.doer)
+	}
+	if f.logger == nil || f.handler == nil {
+		t.Fatalf("New() logger = %v, handler = %v", f.logger, f.handler)
+	}
+	f.logger.DebugContext(ctx, "discarded")
+
+	f = New(ctx, nil, WithMiddleware(stubMiddleware))
+	if _, err := f.DomainFilling(ctx, &QueryRequest{UnitName: "baidu.com"}); err != nil {
+		t.Errorf("DomainFilling() error = %v", err)
+	}
+}
+
+func TestNewWithError(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{name: "no options"},
+		{name: "doer and slog", opts: []Option{WithDoer(http.DefaultClient), WithSlog(slog.Default())}},
+		{name: "request and doer", opts: []Option{WithRequest(request.NewDefaultRequest()), WithDoer(http.DefaultClient)}, wantErr: true},
+		{name: "logger and slog", opts: []Option{WithLogger(logger.NewDefaultLogger()), WithSlog(slog.Default())}, wantErr: true},
+		{name: "negative max response size", opts: []Option{WithMaxResponseSize(-1)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewWithError(ctx, tt.opts...)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidOption)) {
+				t.Fatalf("NewWithError() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("NewWithError() got nil")
+			}
+		})
+	}
+}
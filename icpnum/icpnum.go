@@ -0,0 +1,193 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+// Package icpnum parses ICP filing and licence numbers (备案号), such as
+// 京ICP备030173号-1, 京ICP证030173号 or 京公网安备11000002000001号.
+package icpnum
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Type is the kind of a number
+type Type int
+
+// Types of numbers
+const (
+	// Filing is an ICP filing, ICP备
+	Filing Type = iota + 1
+	// Licence is an ICP commercial licence, ICP证
+	Licence
+	// PublicSecurity is a public security filing, 公网安备
+	PublicSecurity
+)
+
+// typeNames are the canonical spellings of the types
+var typeNames = map[Type]string{
+	Filing:         "ICP备",
+	Licence:        "ICP证",
+	PublicSecurity: "公网安备",
+}
+
+// String return the canonical spelling of t
+func (t Type) String() string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return "Type(" + strconv.Itoa(int(t)) + ")"
+}
+
+// provinces map the abbreviations to the province names, both abbreviations
+// of Sichuan, Guizhou, Yunnan, Shaanxi and Gansu are in use
+var provinces = map[string]string{
+	"京": "北京", "津": "天津", "冀": "河北", "晋": "山西", "蒙": "内蒙古",
+	"辽": "辽宁", "吉": "吉林", "黑": "黑龙江", "沪": "上海", "苏": "江苏",
+	"浙": "浙江", "皖": "安徽", "闽": "福建", "赣": "江西", "鲁": "山东",
+	"豫": "河南", "鄂": "湖北", "湘": "湖南", "粤": "广东", "桂": "广西",
+	"琼": "海南", "渝": "重庆", "川": "四川", "蜀": "四川", "贵": "贵州",
+	"黔": "贵州", "云": "云南", "滇": "云南", "藏": "西藏", "陕": "陕西",
+	"秦": "陕西", "甘": "甘肃", "陇": "甘肃", "青": "青海", "宁": "宁夏",
+	"新": "新疆",
+}
+
+// pattern matches a normalized number
+var pattern = regexp.MustCompile(`^(\p{Han})(ICP备|ICP证|公网安备)(\d+)号?(?:-(\d+))?$`)
+
+// ErrInvalid is returned for a malformed number
+var ErrInvalid = errors.New("invalid ICP number")
+
+// Number is a parsed number
+type Number struct {
+	// Province is the province abbreviation, such as 京
+	Province string
+	// Type is the kind of the number
+	Type Type
+	// Serial is the serial number with its leading zeros
+	Serial string
+	// Site is the site suffix after the dash, 0 without suffix
+	Site int
+}
+
+// Parse parse s after Normalize, it fails with an error wrapping ErrInvalid
+func Parse(s string) (*Number, error) {
+	m := pattern.FindStringSubmatch(Normalize(s))
+	if m == nil {
+		return nil, fmt.Errorf("%w: malformed number %q", ErrInvalid, s)
+	}
+	n := &Number{Province: m[1], Serial: m[3]}
+	if _, ok := provinces[n.Province]; !ok {
+		return nil, fmt.Errorf("%w: unknown province %q", ErrInvalid, n.Province)
+	}
+	for t, name := range typeNames {
+		if m[2] == name {
+			n.Type = t
+		}
+	}
+	if m[4] != "" {
+		site, err := strconv.Atoi(m[4])
+		if err != nil || site < 1 {
+			return nil, fmt.Errorf("%w: invalid site suffix %q", ErrInvalid, m[4])
+		}
+		n.Site = site
+	}
+	if err := n.validate(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// validate check the serial length of the type
+func (n *Number) validate() error {
+	switch n.Type {
+	case PublicSecurity:
+		if len(n.Serial) != 14 || n.Site != 0 {
+			return fmt.Errorf("%w: a public security number has 14 digits and no site suffix", ErrInvalid)
+		}
+	default:
+		// 6 to 8 digits, or a year and 6 digits since 2015
+		if len(n.Serial) < 6 || len(n.Serial) > 10 {
+			return fmt.Errorf("%w: an ICP serial has 6 to 10 digits", ErrInvalid)
+		}
+	}
+	return nil
+}
+
+// Valid reports whether s is a well-formed number
+func Valid(s string) bool {
+	_, err := Parse(s)
+	return err == nil
+}
+
+// String return the canonical form of n, such as 京ICP备030173号-1
+func (n *Number) String() string {
+	var b strings.Builder
+	b.WriteString(n.Province)
+	b.WriteString(n.Type.String())
+	b.WriteString(n.Serial)
+	b.WriteString("号")
+	if n.Site > 0 {
+		b.WriteString("-")
+		b.WriteString(strconv.Itoa(n.Site))
+	}
+	return b.String()
+}
+
+// Main return n without its site suffix, the number of the filing entity
+func (n *Number) Main() *Number {
+	m := *n
+	m.Site = 0
+	return &m
+}
+
+// Equal reports whether n and o are the same number
+func (n *Number) Equal(o *Number) bool {
+	return n != nil && o != nil && *n == *o
+}
+
+// ProvinceName return the name of the province, such as 北京
+func (n *Number) ProvinceName() string {
+	return provinces[n.Province]
+}
+
+// dashes are the characters normalized to a hyphen
+var dashes = map[rune]bool{'‐': true, '‑': true, '‒': true, '–': true, '—': true, '−': true, '﹣': true}
+
+// Normalize fold full-width characters to half-width, dashes to a hyphen and
+// icp to upper case, and drop the spaces
+func Normalize(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		switch {
+		case r >= '！' && r <= '～':
+			r -= '！' - '!'
+		case dashes[r]:
+			r = '-'
+		}
+		if unicode.IsSpace(r) {
+			continue
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,89 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package icpnum
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Number
+		canon   string
+		wantErr bool
+	}{
+		{in: "京ICP备030173号-1", want: Number{Province: "京", Type: Filing, Serial: "030173", Site: 1}, canon: "京ICP备030173号-1"},
+		{in: "京ICP证030173号", want: Number{Province: "京", Type: Licence, Serial: "030173"}, canon: "京ICP证030173号"},
+		{in: "粤ICP备2021012345号-12", want: Number{Province: "粤", Type: Filing, Serial: "2021012345", Site: 12}, canon: "粤ICP备2021012345号-12"},
+		{in: "京公网安备11000002000001号", want: Number{Province: "京", Type: PublicSecurity, Serial: "11000002000001"}, canon: "京公网安备11000002000001号"},
+		{in: "京ＩＣＰ备０３０１７３号－１", want: Number{Province: "京", Type: Filing, Serial: "030173", Site: 1}, canon: "京ICP备030173号-1"},
+		{in: " 京icp备 030173号 — 1 ", want: Number{Province: "京", Type: Filing, Serial: "030173", Site: 1}, canon: "京ICP备030173号-1"},
+		{in: "浙ICP备030173-2", want: Number{Province: "浙", Type: Filing, Serial: "030173", Site: 2}, canon: "浙ICP备030173号-2"},
+		{in: "", wantErr: true},
+		{in: "ICP备030173号", wantErr: true},
+		{in: "美ICP备030173号", wantErr: true},
+		{in: "京ICP备123号", wantErr: true},
+		{in: "京ICP备030173号-0", wantErr: true},
+		{in: "京公网安备110000号", wantErr: true},
+		{in: "京公网安备11000002000001号-1", wantErr: true},
+		{in: "京B2-20090059", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("Parse() error = %v, want ErrInvalid", err)
+				}
+				return
+			}
+			if *got != tt.want {
+				t.Errorf("Parse() = %+v, want %+v", *got, tt.want)
+			}
+			if got.String() != tt.canon {
+				t.Errorf("String() = %q, want %q", got.String(), tt.canon)
+			}
+			if again, err := Parse(got.String()); err != nil || !again.Equal(got) {
+				t.Errorf("Parse(String()) = %v, %v, want %v", again, err, got)
+			}
+		})
+	}
+}
+
+func TestNumber_Main(t *testing.T) {
+	service, _ := Parse("京ICP证030173号-1")
+	entity, _ := Parse("京ICP证030173号")
+	if !service.Main().Equal(entity) || service.Equal(entity) {
+		t.Errorf("Main() = %v, want %v", service.Main(), entity)
+	}
+	if service.Site != 1 {
+		t.Errorf("Main() modified the number")
+	}
+	if got := service.ProvinceName(); got != "北京" {
+		t.Errorf("ProvinceName() = %q, want 北京", got)
+	}
+	if Valid("京ICP证") || !Valid("京ICP证030173号") {
+		t.Errorf("Valid() is wrong")
+	}
+}
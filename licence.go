@@ -0,0 +1,133 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/houseme/icp-filing/icpnum"
+)
+
+// maxPages bounds the pages read by one lookup across pages
+const maxPages = 25
+
+// ErrTooManyRecords is returned by the lookups across pages, such as
+// QueryByLicence, QueryEntity and Snapshot, when the upstream has more than
+// maxPages pages of records, rather than an incomplete list
+var ErrTooManyRecords = errors.New("too many records")
+
+// LicenceMatch selects the records returned by QueryByLicence
+type LicenceMatch int
+
+// Licence matches
+const (
+	// MatchAny keeps the records of the entity of the number, by their main
+	// licence or by their service licence without its site suffix
+	MatchAny LicenceMatch = iota
+	// MatchMain keeps the records whose main licence is the number without its site suffix
+	MatchMain
+	// MatchService keeps the records whose service licence is the number
+	MatchService
+)
+
+// QueryByLicence return the website records of a licence number, such as
+// 京ICP备030173号-1, read across every page. The number is validated with
+// icpnum.Parse, the error wraps icpnum.ErrInvalid when it is malformed or a
+// public security number, which the MIIT search does not index.
+func (i *Filling) QueryByLicence(ctx context.Context, licence string, match LicenceMatch) ([]*DomainInfo, error) {
+	number, err := icpnum.Parse(licence)
+	if err != nil {
+		return nil, err
+	}
+	if number.Type == icpnum.PublicSecurity {
+		return nil, fmt.Errorf("%w: %s is not an ICP number", icpnum.ErrInvalid, number)
+	}
+	// the upstream finds the entity by its main licence
+	entity := number.Main()
+
+	all, err := i.queryAll(ctx, NewQuery(entity.String()))
+	if err != nil {
+		return nil, err
+	}
+	var records []*DomainInfo
+	for _, info := range all {
+		if matchLicence(info, number, entity, match) {
+			records = append(records, info)
+		}
+	}
+	return records, nil
+}
+
+// queryAll return the records of req read across every page of MaxPageSize,
+// the error wraps ErrTooManyRecords when there are more than maxPages pages
+func (i *Filling) queryAll(ctx context.Context, req *QueryRequest) ([]*DomainInfo, error) {
+	var records []*DomainInfo
+	for page := 1; ; page++ {
+		if page > maxPages {
+			return nil, fmt.Errorf("%w: more than %d", ErrTooManyRecords, maxPages*MaxPageSize)
+		}
+		resp, err := i.DomainFilling(ctx, req.Page(page).Size(MaxPageSize))
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil {
+			return nil, errors.New("response is nil")
+		}
+		if !resp.Success {
+			return nil, errors.New("code: " + strconv.Itoa(resp.Code) + " errMsg: " + resp.Msg)
+		}
+		if resp.Params == nil || len(resp.Params.List) == 0 {
+			break
+		}
+		records = append(records, resp.Params.List...)
+		if !resp.Params.HasNextPage && page*MaxPageSize >= resp.Params.Total {
+			break
+		}
+	}
+	return records, nil
+}
+
+// matchLicence reports whether info matches the number
+func matchLicence(info *DomainInfo, number, entity *icpnum.Number, match LicenceMatch) bool {
+	switch match {
+	case MatchMain:
+		return sameLicence(info.MainLicence, entity)
+	case MatchService:
+		return sameLicence(info.ServiceLicence, number)
+	default:
+		if sameLicence(info.MainLicence, entity) {
+			return true
+		}
+		service, err := icpnum.Parse(info.ServiceLicence)
+		return err == nil && service.Main().Equal(entity)
+	}
+}
+
+// sameLicence reports whether the reported licence s is n
+func sameLicence(s string, n *icpnum.Number) bool {
+	reported, err := icpnum.Parse(s)
+	if err != nil {
+		return strings.EqualFold(icpnum.Normalize(s), n.String())
+	}
+	return reported.Equal(n)
+}
@@ -0,0 +1,109 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/houseme/icp-filing/icpnum"
+)
+
+func TestFilling_QueryByLicence(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		queried []QueryRequest
+	)
+	f := New(ctx, WithDoer(recordsDoer(func(in QueryRequest) []*DomainInfo {
+		queried = append(queried, in)
+		records := make([]*DomainInfo, 42)
+		for n := range records {
+			mainLicence := "京ICP证030173号"
+			if n == 41 {
+				mainLicence = "京ICP证030174号"
+			}
+			records[n] = &DomainInfo{
+				Domain:         "site" + strconv.Itoa(n) + ".com",
+				MainLicence:    mainLicence,
+				ServiceLicence: mainLicence + "-" + strconv.Itoa(n+1),
+			}
+		}
+		// only the service licence tells the entity
+		records[40].MainLicence = ""
+		return records
+	})))
+
+	tests := []struct {
+		licence string
+		match   LicenceMatch
+		want    int
+	}{
+		{licence: "京ICP证030173号-2", match: MatchAny, want: 41},
+		{licence: "京ICP证030173号-2", match: MatchService, want: 1},
+		{licence: "京ＩＣＰ证030173号", match: MatchMain, want: 40},
+	}
+	for _, tt := range tests {
+		queried = nil
+		records, err := f.QueryByLicence(ctx, tt.licence, tt.match)
+		if err != nil {
+			t.Fatalf("QueryByLicence(%s) error = %v", tt.licence, err)
+		}
+		if len(records) != tt.want {
+			t.Errorf("QueryByLicence(%s, %d) = %d records, want %d", tt.licence, tt.match, len(records), tt.want)
+		}
+		if len(queried) != 2 || queried[0].UnitName != "京ICP证030173号" || queried[1].PageNum != 2 || queried[1].PageSize != MaxPageSize {
+			t.Errorf("QueryByLicence(%s) queried %+v", tt.licence, queried)
+		}
+	}
+
+	for _, licence := range []string{"京ICP证", "京公网安备11000002000001号"} {
+		queried = nil
+		if _, err := f.QueryByLicence(ctx, licence, MatchAny); !errors.Is(err, icpnum.ErrInvalid) {
+			t.Errorf("QueryByLicence(%s) error = %v, want icpnum.ErrInvalid", licence, err)
+		}
+		if len(queried) != 0 {
+			t.Errorf("QueryByLicence(%s) queried %+v", licence, queried)
+		}
+	}
+}
+
+func TestFilling_QueryAllTooManyRecords(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		queries int
+	)
+	f := New(ctx, WithDoer(recordsDoer(func(QueryRequest) []*DomainInfo {
+		queries++
+		records := make([]*DomainInfo, (maxPages+1)*MaxPageSize)
+		for n := range records {
+			records[n] = &DomainInfo{Domain: "site" + strconv.Itoa(n) + ".com"}
+		}
+		return records
+	})))
+
+	s, err := f.Snapshot(ctx, "北京百度网讯科技有限公司", ServiceWebsite)
+	if !errors.Is(err, ErrTooManyRecords) || s != nil {
+		t.Errorf("Snapshot() = %v, %v, want ErrTooManyRecords", s, err)
+	}
+	if queries != maxPages {
+		t.Errorf("queries = %d, want %d", queries, maxPages)
+	}
+}
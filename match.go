@@ -0,0 +1,171 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/houseme/icp-filing/tld"
+)
+
+// CoverRule is the rule by which a filing covers a host
+type CoverRule string
+
+// Rules of a coverage, from the strongest to the weakest
+const (
+	CoverNone      CoverRule = ""
+	CoverDomain    CoverRule = "domain"    // the host is the filed domain
+	CoverWWW       CoverRule = "www"       // the host and the filed domain differ by www.
+	CoverHomeURL   CoverRule = "homeUrl"   // the host is a listed home URL, or its www variant
+	CoverSubdomain CoverRule = "subdomain" // the host is under the filed domain or a home URL
+)
+
+// rank return the strength of r, lower is stronger
+func (r CoverRule) rank() int {
+	switch r {
+	case CoverDomain:
+		return 1
+	case CoverWWW:
+		return 2
+	case CoverHomeURL:
+		return 3
+	case CoverSubdomain:
+		return 4
+	default:
+		return 5
+	}
+}
+
+// Coverage tells whether and how a host is covered by a filing
+type Coverage struct {
+	// Host is the host checked, lower-cased, without port
+	Host string `json:"host"`
+	// Domain is the registrable domain of the host
+	Domain string `json:"domain"`
+	// Covered reports whether a record covers the host
+	Covered bool `json:"covered"`
+	// Rule is the rule of the match
+	Rule CoverRule `json:"rule,omitempty"`
+	// Via is the filed domain or home URL host that matched
+	Via string `json:"via,omitempty"`
+	// Record is the matching record
+	Record *DomainInfo `json:"record,omitempty"`
+}
+
+// String return the coverage as JSON, the leader name is redacted
+func (c *Coverage) String() string {
+	if c == nil {
+		return "null"
+	}
+	r := *c
+	r.Record = c.Record.redacted()
+	return toJSON(&r)
+}
+
+// Explain return a sentence describing the match
+func (c *Coverage) Explain() string {
+	if !c.Covered {
+		return fmt.Sprintf("%s is not covered by a filing of %s", c.Host, c.Domain)
+	}
+	var how string
+	switch c.Rule {
+	case CoverDomain:
+		how = "it is the filed domain"
+	case CoverWWW:
+		how = "it is the www variant of the filed domain"
+	case CoverHomeURL:
+		how = "it is a listed home URL"
+	default:
+		how = "it is a subdomain of " + c.Via
+	}
+	s := fmt.Sprintf("%s is covered by the filing of %s, %s", c.Host, strings.TrimSpace(c.Record.Domain), how)
+	if c.Record.ServiceLicence != "" {
+		s += " (" + c.Record.ServiceLicence + ")"
+	}
+	return s
+}
+
+// MatchHost return whether records, such as the list of a DomainFilling
+// answer, cover the host of hostOrURL. A record covers the host when it is
+// the filed domain, its www variant, a home URL or a subdomain of one of them
+// within the same registrable domain. The strongest rule wins, then the first
+// record. The error wraps ErrInvalidDomain when the host has no registrable domain.
+func MatchHost(ctx context.Context, hostOrURL string, records []*DomainInfo) (*Coverage, error) {
+	host := tld.Hostname(strings.TrimSpace(hostOrURL))
+	resp, err := tld.GetTLD(ctx, host, 0)
+	if err != nil || resp.Domain == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidDomain, hostOrURL)
+	}
+	c := &Coverage{Host: host, Domain: resp.Domain}
+	for _, info := range records {
+		if info == nil {
+			continue
+		}
+		if rule, via := coverRule(host, resp.Domain, info); rule.rank() < c.Rule.rank() {
+			c.Covered, c.Rule, c.Via, c.Record = true, rule, via, info
+		}
+	}
+	return c, nil
+}
+
+// coverRule return the strongest rule by which info covers host
+func coverRule(host, domain string, info *DomainInfo) (CoverRule, string) {
+	filed := tld.Hostname(strings.TrimSpace(info.Domain))
+	switch {
+	case filed == "":
+	case host == filed:
+		return CoverDomain, filed
+	case host == "www."+filed || filed == "www."+host:
+		return CoverWWW, filed
+	}
+	homes := homeHosts(info.HomeURL)
+	for _, h := range homes {
+		if host == h || host == "www."+h || h == "www."+host {
+			return CoverHomeURL, h
+		}
+	}
+	for _, h := range append([]string{filed}, homes...) {
+		if within(h, domain) && strings.HasSuffix(host, "."+h) {
+			return CoverSubdomain, h
+		}
+	}
+	return CoverNone, ""
+}
+
+// within reports whether name is domain or one of its subdomains
+func within(name, domain string) bool {
+	return name == domain || strings.HasSuffix(name, "."+domain)
+}
+
+// homeHosts return the hosts of a home URL list, as in "www.a.com;www.b.com"
+func homeHosts(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(";,；，、", r)
+	})
+	hosts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if h := tld.Hostname(f); h != "" {
+			hosts = append(hosts, h)
+		}
+	}
+	return hosts
+}
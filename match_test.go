@@ -0,0 +1,76 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMatchHost(t *testing.T) {
+	records := []*DomainInfo{
+		{Domain: "baidu.com.cn", HomeURL: "www.baidu.com.cn"},
+		{Domain: " BAIDU.com ", HomeURL: "www.baidu.com；https://map.baidu.com/", ServiceLicence: "京ICP证030173号-1"},
+		{Domain: "example.org", HomeURL: "http://shop.example.net:8080/index.html"},
+		{Domain: "www.qq.com"},
+		{},
+		nil,
+	}
+	tests := []struct {
+		host    string
+		domain  string
+		rule    CoverRule
+		via     string
+		licence string
+	}{
+		{host: "https://BAIDU.com/", domain: "baidu.com", rule: CoverDomain, via: "baidu.com", licence: "京ICP证030173号-1"},
+		{host: "www.baidu.com", domain: "baidu.com", rule: CoverWWW, via: "baidu.com"},
+		{host: "qq.com", domain: "qq.com", rule: CoverWWW, via: "www.qq.com"},
+		{host: "www.map.baidu.com", domain: "baidu.com", rule: CoverHomeURL, via: "map.baidu.com"},
+		{host: "shop.example.net", domain: "example.net", rule: CoverHomeURL, via: "shop.example.net"},
+		{host: "a.b.c.baidu.com:443", domain: "baidu.com", rule: CoverSubdomain, via: "baidu.com"},
+		{host: "cdn.shop.example.net", domain: "example.net", rule: CoverSubdomain, via: "shop.example.net"},
+		{host: "example.net", domain: "example.net"},
+		{host: "fake-baidu.com", domain: "fake-baidu.com"},
+		{host: "img.qq.com", domain: "qq.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			c, err := MatchHost(context.Background(), tt.host, records)
+			if err != nil {
+				t.Fatalf("MatchHost() error = %v", err)
+			}
+			if c.Domain != tt.domain || c.Rule != tt.rule || c.Via != tt.via || c.Covered != (tt.rule != CoverNone) {
+				t.Errorf("MatchHost() = %s, want domain %q rule %q via %q", c, tt.domain, tt.rule, tt.via)
+			}
+			if tt.licence != "" && !strings.Contains(c.Explain(), tt.licence) {
+				t.Errorf("Explain() = %q, want the licence %s", c.Explain(), tt.licence)
+			}
+			if !c.Covered && !strings.Contains(c.Explain(), "not covered") {
+				t.Errorf("Explain() = %q", c.Explain())
+			}
+		})
+	}
+
+	if _, err := MatchHost(context.Background(), "localhost", records); !errors.Is(err, ErrInvalidDomain) {
+		t.Errorf("MatchHost(localhost) error = %v, want ErrInvalidDomain", err)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"errors"
+	"time"
+
+	"github.com/houseme/icp-filing/utility/request"
+)
+
+// Outcomes of an upstream call reported to Metrics
+const (
+	OutcomeSuccess   = "success"
+	OutcomeHTTPError = "http_error"
+	OutcomeChallenge = "challenge"
+	OutcomeTooLarge  = "too_large"
+	OutcomeError     = "error"
+)
+
+// tokenExpiredCode is the business code the upstream answers with when the token is invalid or expired
+const tokenExpiredCode = 401
+
+// Metrics receives the measurements of the client, see the metrics package
+// for a Prometheus implementation. Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveRequest records one upstream HTTP call by API path and outcome
+	ObserveRequest(path, outcome string, duration time.Duration)
+	// IncAuthRefresh records one authorize call
+	IncAuthRefresh(success bool)
+	// IncTokenExpired records a query rejected because the token expired
+	IncTokenExpired()
+	// ObserveCache records one response cache lookup
+	ObserveCache(hit bool)
+	// ObserveRateLimitWait records the time spent waiting for the rate limiter
+	ObserveRateLimitWait(duration time.Duration)
+	// IncUpstreamCode records the business code of an upstream answer
+	IncUpstreamCode(path string, code int)
+}
+
+// WithMetrics is the option for metrics.
+func WithMetrics(m Metrics) Option {
+	return func(o *options) {
+		o.Metrics = m
+	}
+}
+
+// nopMetrics is the Metrics used when none is set
+type nopMetrics struct{}
+
+// ObserveRequest implements Metrics, it does nothing
+func (nopMetrics) ObserveRequest(string, string, time.Duration) {}
+
+// IncAuthRefresh implements Metrics, it does nothing
+func (nopMetrics) IncAuthRefresh(bool) {}
+
+// IncTokenExpired implements Metrics, it does nothing
+func (nopMetrics) IncTokenExpired() {}
+
+// ObserveCache implements Metrics, it does nothing
+func (nopMetrics) ObserveCache(bool) {}
+
+// ObserveRateLimitWait implements Metrics, it does nothing
+func (nopMetrics) ObserveRateLimitWait(time.Duration) {}
+
+// IncUpstreamCode implements Metrics, it does nothing
+func (nopMetrics) IncUpstreamCode(string, int) {}
+
+// outcome classify the result of an upstream call
+func outcome(c *Challenge, err error) string {
+	var httpErr *request.HTTPError
+	switch {
+	case c != nil:
+		return OutcomeChallenge
+	case err == nil:
+		return OutcomeSuccess
+	case errors.Is(err, request.ErrResponseTooLarge):
+		return OutcomeTooLarge
+	case errors.As(err, &httpErr):
+		return OutcomeHTTPError
+	default:
+		return OutcomeError
+	}
+}
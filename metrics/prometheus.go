@@ -0,0 +1,136 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+// Package metrics is the Prometheus implementation of the filling metrics
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultNamespace is the namespace of the metric names
+const DefaultNamespace = "icp_filing"
+
+// Prometheus implements filling.Metrics with Prometheus collectors
+type Prometheus struct {
+	requests       *prometheus.CounterVec
+	requestLatency *prometheus.HistogramVec
+	authRefreshes  *prometheus.CounterVec
+	tokenExpired   prometheus.Counter
+	cache          *prometheus.CounterVec
+	rateLimitWait  prometheus.Histogram
+	upstreamCodes  *prometheus.CounterVec
+}
+
+// NewPrometheus create the collectors under namespace, DefaultNamespace when
+// empty, and register them with reg, prometheus.DefaultRegisterer when nil.
+func NewPrometheus(reg prometheus.Registerer, namespace string) (*Prometheus, error) {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	p := &Prometheus{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Upstream HTTP calls by API path and outcome.",
+		}, []string{"path", "outcome"}),
+		requestLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of upstream HTTP calls by API path and outcome.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"path", "outcome"}),
+		authRefreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "auth_refreshes_total",
+			Help:      "Authorize calls by result.",
+		}, []string{"result"}),
+		tokenExpired: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "token_expirations_total",
+			Help:      "Queries rejected because the token expired.",
+		}),
+		cache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_requests_total",
+			Help:      "Response cache lookups by result.",
+		}, []string{"result"}),
+		rateLimitWait: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "rate_limit_wait_seconds",
+			Help:      "Time spent waiting for the rate limiter.",
+			Buckets:   []float64{0, .005, .01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}),
+		upstreamCodes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "upstream_codes_total",
+			Help:      "Business codes answered by the upstream by API path.",
+		}, []string{"path", "code"}),
+	}
+	for _, c := range []prometheus.Collector{p.requests, p.requestLatency, p.authRefreshes, p.tokenExpired, p.cache, p.rateLimitWait, p.upstreamCodes} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// ObserveRequest implements filling.Metrics
+func (p *Prometheus) ObserveRequest(path, outcome string, duration time.Duration) {
+	p.requests.WithLabelValues(path, outcome).Inc()
+	p.requestLatency.WithLabelValues(path, outcome).Observe(duration.Seconds())
+}
+
+// IncAuthRefresh implements filling.Metrics
+func (p *Prometheus) IncAuthRefresh(success bool) {
+	p.authRefreshes.WithLabelValues(result(success, "success", "failure")).Inc()
+}
+
+// IncTokenExpired implements filling.Metrics
+func (p *Prometheus) IncTokenExpired() {
+	p.tokenExpired.Inc()
+}
+
+// ObserveCache implements filling.Metrics
+func (p *Prometheus) ObserveCache(hit bool) {
+	p.cache.WithLabelValues(result(hit, "hit", "miss")).Inc()
+}
+
+// ObserveRateLimitWait implements filling.Metrics
+func (p *Prometheus) ObserveRateLimitWait(duration time.Duration) {
+	p.rateLimitWait.Observe(duration.Seconds())
+}
+
+// IncUpstreamCode implements filling.Metrics
+func (p *Prometheus) IncUpstreamCode(path string, code int) {
+	p.upstreamCodes.WithLabelValues(path, strconv.Itoa(code)).Inc()
+}
+
+// result return yes when ok, otherwise no
+func result(ok bool, yes, no string) string {
+	if ok {
+		return yes
+	}
+	return no
+}
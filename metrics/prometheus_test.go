@@ -0,0 +1,109 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	filling "github.com/houseme/icp-filing"
+	"github.com/houseme/icp-filing/metrics"
+	"github.com/houseme/icp-filing/utility/request"
+)
+
+var _ filling.Metrics = (*metrics.Prometheus)(nil)
+
+func TestPrometheus(t *testing.T) {
+	var (
+		ctx = context.Background()
+		reg = prometheus.NewRegistry()
+	)
+	m, err := metrics.NewPrometheus(reg, "")
+	if err != nil {
+		t.Fatalf("NewPrometheus() error = %v", err)
+	}
+	if _, err = metrics.NewPrometheus(reg, ""); err == nil {
+		t.Errorf("NewPrometheus() registered the collectors twice")
+	}
+
+	var queries int
+	doer := request.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		status, body := http.StatusOK, `{"code":200,"success":true,"params":{"bussiness":"token","expire":300000}}`
+		if strings.HasSuffix(req.URL.Path, "queryByCondition") {
+			if queries++; queries == 1 {
+				body = `{"code":401,"msg":"token expired","success":false}`
+			} else {
+				body = `{"code":200,"success":true,"params":{"list":[],"total":0}}`
+			}
+		}
+		return &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	f := filling.New(ctx, filling.WithDoer(doer), filling.WithMetrics(m),
+		filling.WithCache(filling.NewMemoryCache(time.Minute, 0)), filling.WithRateLimiter(unlimited{}))
+	for n := 0; n < 2; n++ {
+		if _, err = f.DomainFilling(ctx, &filling.QueryRequest{UnitName: "baidu.com"}); err != nil {
+			t.Fatalf("DomainFilling() error = %v", err)
+		}
+	}
+
+	const want = `
+# HELP icp_filing_auth_refreshes_total Authorize calls by result.
+# TYPE icp_filing_auth_refreshes_total counter
+icp_filing_auth_refreshes_total{result="success"} 2
+# HELP icp_filing_requests_total Upstream HTTP calls by API path and outcome.
+# TYPE icp_filing_requests_total counter
+icp_filing_requests_total{outcome="success",path="auth"} 2
+icp_filing_requests_total{outcome="success",path="icpAbbreviateInfo/queryByCondition"} 2
+# HELP icp_filing_token_expirations_total Queries rejected because the token expired.
+# TYPE icp_filing_token_expirations_total counter
+icp_filing_token_expirations_total 1
+# HELP icp_filing_upstream_codes_total Business codes answered by the upstream by API path.
+# TYPE icp_filing_upstream_codes_total counter
+icp_filing_upstream_codes_total{code="200",path="auth"} 2
+icp_filing_upstream_codes_total{code="200",path="icpAbbreviateInfo/queryByCondition"} 1
+icp_filing_upstream_codes_total{code="401",path="icpAbbreviateInfo/queryByCondition"} 1
+# HELP icp_filing_cache_requests_total Response cache lookups by result.
+# TYPE icp_filing_cache_requests_total counter
+icp_filing_cache_requests_total{result="hit"} 1
+icp_filing_cache_requests_total{result="miss"} 1
+`
+	if err = testutil.GatherAndCompare(reg, strings.NewReader(want),
+		"icp_filing_auth_refreshes_total", "icp_filing_requests_total",
+		"icp_filing_token_expirations_total", "icp_filing_upstream_codes_total", "icp_filing_cache_requests_total"); err != nil {
+		t.Error(err)
+	}
+	if got := testutil.CollectAndCount(reg, "icp_filing_request_duration_seconds"); got != 2 {
+		t.Errorf("request_duration_seconds series = %d, want 2", got)
+	}
+	if got := testutil.CollectAndCount(reg, "icp_filing_rate_limit_wait_seconds"); got != 1 {
+		t.Errorf("rate_limit_wait_seconds series = %d, want 1", got)
+	}
+}
+
+// unlimited is a RateLimiter that never waits
+type unlimited struct{}
+
+func (unlimited) Wait(context.Context) error { return nil }
@@ -0,0 +1,50 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+
+	"github.com/houseme/icp-filing/utility/request"
+)
+
+// Handler executes one upstream call described by in with the given headers
+// and returns the response envelope, which carries the raw body.
+type Handler func(ctx context.Context, in *ParamInput, headMap map[string]string) (*request.Response, error)
+
+// Middleware wraps a Handler, it may inspect or modify the input and headers,
+// inspect the raw response, or short-circuit the call entirely.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware is the option for middleware, the first one is the outermost.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(o *options) {
+		o.Middlewares = append(o.Middlewares, mw...)
+	}
+}
+
+// chain wraps h with mw so that mw[0] is invoked first
+func chain(h Handler, mw []Middleware) Handler {
+	for i := len(mw) - 1; i >= 0; i-- {
+		if mw[i] != nil {
+			h = mw[i](h)
+		}
+	}
+	return h
+}
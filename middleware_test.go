@@ -0,0 +1,91 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/houseme/icp-filing/utility/logger"
+	"github.com/houseme/icp-filing/utility/request"
+)
+
+const (
+	testAuthBody  = `{"code":200,"msg":"操作成功","success":true,"params":{"bussiness":"test-token","expire":300000,"refresh":"r"}}`
+	testQueryBody = `{"code":200,"msg":"操作成功","success":true,"params":{"list":[{"domain":"baidu.com","mainLicence":"京ICP证030173号","serviceLicence":"京ICP证030173号-1","unitName":"北京百度网讯科技有限公司","natureName":"企业"}],"pageNum":1,"pageSize":10,"total":1}}`
+)
+
+// stubMiddleware answers every upstream call from memory
+func stubMiddleware(_ Handler) Handler {
+	return func(_ context.Context, in *ParamInput, _ map[string]string) (*request.Response, error) {
+		body := testQueryBody
+		if in.Path == authorizePath {
+			body = testAuthBody
+		}
+		return &request.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: []byte(body), Attempts: 1}, nil
+	}
+}
+
+func TestFilling_WithMiddleware(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		order  []string
+		tokens []string
+		trace  = func(name string) Middleware {
+			return func(next Handler) Handler {
+				return func(ctx context.Context, in *ParamInput, headMap map[string]string) (*request.Response, error) {
+					order = append(order, name+":"+in.Path)
+					headMap["X-Trace"] = name
+					resp, err := next(ctx, in, headMap)
+					if err == nil && len(resp.Body) == 0 {
+						t.Errorf("%s: empty response", name)
+					}
+					return resp, err
+				}
+			}
+		}
+		capture = func(next Handler) Handler {
+			return func(ctx context.Context, in *ParamInput, headMap map[string]string) (*request.Response, error) {
+				tokens = append(tokens, headMap["Token"])
+				return next(ctx, in, headMap)
+			}
+		}
+		f = New(ctx, WithLogger(logger.NewDefaultLogger()),
+			WithMiddleware(trace("outer"), trace("inner")), WithMiddleware(capture, stubMiddleware))
+	)
+
+	got, err := f.DomainFilling(ctx, &QueryRequest{UnitName: "baidu.com", ServiceType: 1})
+	if err != nil {
+		t.Fatalf("DomainFilling() error = %v", err)
+	}
+	if got.Params == nil || len(got.Params.List) != 1 || got.Params.List[0].Domain != "baidu.com" {
+		t.Errorf("DomainFilling() got = %v", got)
+	}
+
+	wantOrder := []string{"outer:" + authorizePath, "inner:" + authorizePath, "outer:" + queryPath, "inner:" + queryPath}
+	if !reflect.DeepEqual(order, wantOrder) {
+		t.Errorf("middleware order = %v, want %v", order, wantOrder)
+	}
+	wantTokens := []string{defaultToken, "test-token"}
+	if !reflect.DeepEqual(tokens, wantTokens) {
+		t.Errorf("middleware tokens = %v, want %v", tokens, wantTokens)
+	}
+}
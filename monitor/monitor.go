@@ -0,0 +1,362 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+// Package monitor re-checks the filings of domains on a schedule and emits
+// an event for every record added, removed or changed.
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	filing "github.com/houseme/icp-filing"
+	"github.com/houseme/icp-filing/tld"
+)
+
+// DefaultTimeout is the default timeout of one check
+const DefaultTimeout = 30 * time.Second
+
+var (
+	// ErrInvalidInterval is returned by Add for an interval that is not positive
+	ErrInvalidInterval = errors.New("monitor: invalid interval")
+
+	// ErrRunning is returned by Run when the monitor is already running
+	ErrRunning = errors.New("monitor: already running")
+)
+
+// Snapshotter takes the snapshot of a query, it is implemented by *filing.Filling
+type Snapshotter interface {
+	Snapshot(ctx context.Context, query string, t filing.ServiceType) (*filing.Snapshot, error)
+}
+
+// EventType is the type of an event
+type EventType string
+
+// Types of event
+const (
+	FilingAdded   EventType = "filingAdded"
+	FilingRemoved EventType = "filingRemoved"
+	FilingChanged EventType = "filingChanged"
+	CheckFailed   EventType = "checkFailed"
+)
+
+// Event is the outcome of a check
+type Event struct {
+	// Type is the type of the event
+	Type EventType
+	// Domain is the registrable domain checked
+	Domain string
+	// Time is the time of the check
+	Time time.Time
+	// Change is the record added, removed or changed, nil when the check failed
+	Change *filing.RecordChange
+	// Snapshot is the snapshot of the check, nil when the check failed
+	Snapshot *filing.Snapshot
+	// Err is the error of a failed check
+	Err error
+}
+
+// Handler receives the events, it is called by the goroutine of Run
+type Handler func(ctx context.Context, e Event)
+
+// Monitor checks the filings of its domains at their interval. The checks run
+// one at a time, so they stay within the rate limit of the Filling.
+type Monitor struct {
+	snapshotter Snapshotter
+	clock       Clock
+	handler     Handler
+	timeout     time.Duration
+
+	mu      sync.Mutex
+	watches map[string]*watch
+	wake    chan struct{}
+	running atomic.Bool
+}
+
+// watch is a registered domain
+type watch struct {
+	domain string
+	every  time.Duration
+	next   time.Time
+	last   *filing.Snapshot
+}
+
+type options struct {
+	Clock   Clock
+	Handler Handler
+	Timeout time.Duration
+}
+
+// Option is the option of the monitor.
+type Option func(o *options)
+
+// WithClock is the option for the time source, SystemClock by default.
+func WithClock(c Clock) Option {
+	return func(o *options) {
+		o.Clock = c
+	}
+}
+
+// WithHandler is the option for the handler of the events.
+func WithHandler(h Handler) Option {
+	return func(o *options) {
+		o.Handler = h
+	}
+}
+
+// WithChannel is the option to send the events on ch, Run blocks until ch
+// accepts an event or its context is done.
+func WithChannel(ch chan<- Event) Option {
+	return WithHandler(func(ctx context.Context, e Event) {
+		select {
+		case ch <- e:
+		case <-ctx.Done():
+		}
+	})
+}
+
+// WithTimeout is the option for the timeout of one check.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.Timeout = d
+	}
+}
+
+// New return a monitor checking with s, such as a *filing.Filling configured
+// with a rate limiter. Without handler the events are dropped.
+func New(s Snapshotter, opts ...Option) *Monitor {
+	op := options{
+		Clock:   SystemClock,
+		Timeout: DefaultTimeout,
+	}
+	for _, option := range opts {
+		option(&op)
+	}
+	if op.Clock == nil {
+		op.Clock = SystemClock
+	}
+	if op.Handler == nil {
+		op.Handler = func(context.Context, Event) {}
+	}
+	if op.Timeout <= 0 {
+		op.Timeout = DefaultTimeout
+	}
+	return &Monitor{
+		snapshotter: s,
+		clock:       op.Clock,
+		handler:     op.Handler,
+		timeout:     op.Timeout,
+		watches:     make(map[string]*watch),
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Add register the registrable domain of hostOrURL and return it, it is
+// checked now and then every interval. last is the snapshot of a previous check, such as one
+// loaded from a store, nil to take the first check as the baseline. Adding a
+// registered domain replaces its interval and snapshot.
+func (m *Monitor) Add(hostOrURL string, every time.Duration, last *filing.Snapshot) (string, error) {
+	if every <= 0 {
+		return "", fmt.Errorf("%w: %s", ErrInvalidInterval, every)
+	}
+	resp, err := tld.GetTLD(context.Background(), tld.Hostname(hostOrURL), 0)
+	if err != nil || resp.Domain == "" {
+		return "", fmt.Errorf("%w: %q", filing.ErrInvalidDomain, hostOrURL)
+	}
+	m.mu.Lock()
+	m.watches[resp.Domain] = &watch{domain: resp.Domain, every: every, next: m.clock.Now(), last: last}
+	m.mu.Unlock()
+	m.notify()
+	return resp.Domain, nil
+}
+
+// Remove unregister domain, it reports whether domain was registered
+func (m *Monitor) Remove(domain string) bool {
+	m.mu.Lock()
+	_, ok := m.watches[domain]
+	delete(m.watches, domain)
+	m.mu.Unlock()
+	m.notify()
+	return ok
+}
+
+// Domains return the registered domains, sorted
+func (m *Monitor) Domains() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	domains := make([]string, 0, len(m.watches))
+	for d := range m.watches {
+		domains = append(domains, d)
+	}
+	sort.Strings(domains)
+	return domains
+}
+
+// Last return the snapshot of the last successful check of domain, for
+// storage, nil before the first one
+func (m *Monitor) Last(domain string) *filing.Snapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if w := m.watches[domain]; w != nil {
+		return w.last
+	}
+	return nil
+}
+
+// notify wakes Run up to reschedule
+func (m *Monitor) notify() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run checks the domains when they are due until ctx is done, and returns
+// the error of ctx.
+func (m *Monitor) Run(ctx context.Context) error {
+	if !m.running.CompareAndSwap(false, true) {
+		return ErrRunning
+	}
+	defer m.running.Store(false)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		due, next := m.due()
+		for _, w := range due {
+			m.check(ctx, w)
+		}
+		if len(due) > 0 {
+			continue
+		}
+
+		var (
+			timer Timer
+			fire  <-chan time.Time
+		)
+		if !next.IsZero() {
+			timer = m.clock.NewTimer(next.Sub(m.clock.Now()))
+			fire = timer.C()
+		}
+		select {
+		case <-ctx.Done():
+		case <-m.wake:
+		case <-fire:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// due return the watches due now, by time then domain, and the time of the
+// next one otherwise
+func (m *Monitor) due() ([]*watch, time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var (
+		now  = m.clock.Now()
+		due  []*watch
+		next time.Time
+	)
+	for _, w := range m.watches {
+		if !w.next.After(now) {
+			due = append(due, w)
+		} else if next.IsZero() || w.next.Before(next) {
+			next = w.next
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].next.Equal(due[j].next) {
+			return due[i].next.Before(due[j].next)
+		}
+		return due[i].domain < due[j].domain
+	})
+	return due, next
+}
+
+// check take a snapshot of w, emit the changes since the last one and
+// schedule the next check
+func (m *Monitor) check(ctx context.Context, w *watch) {
+	cctx, cancel := context.WithTimeout(ctx, m.timeout)
+	s, err := m.snapshotter.Snapshot(cctx, w.domain, filing.ServiceWebsite)
+	cancel()
+	if err == nil && s == nil {
+		err = errors.New("snapshot is nil")
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	now := m.clock.Now()
+
+	m.mu.Lock()
+	if m.watches[w.domain] != w {
+		// removed or replaced during the check
+		m.mu.Unlock()
+		return
+	}
+	if w.next = w.next.Add(w.every); !w.next.After(now) {
+		w.next = now.Add(w.every)
+	}
+	last := w.last
+	if err == nil {
+		s = own(s, w.domain)
+		w.last = s
+	}
+	m.mu.Unlock()
+
+	if err != nil {
+		m.handler(ctx, Event{Type: CheckFailed, Domain: w.domain, Time: now, Err: err})
+		return
+	}
+	if last == nil {
+		return
+	}
+	for _, c := range filing.Diff(last, s).All() {
+		e := Event{Domain: w.domain, Time: now, Change: c, Snapshot: s}
+		switch c.Kind {
+		case filing.ChangeAdded:
+			e.Type = FilingAdded
+		case filing.ChangeRemoved:
+			e.Type = FilingRemoved
+		default:
+			e.Type = FilingChanged
+		}
+		m.handler(ctx, e)
+	}
+}
+
+// own return a copy of s with the records of domain only, as the upstream
+// also answers similar domains
+func own(s *filing.Snapshot, domain string) *filing.Snapshot {
+	c := *s
+	c.Records = make([]*filing.DomainInfo, 0, len(s.Records))
+	for _, info := range s.Records {
+		if info != nil && strings.EqualFold(strings.TrimSpace(info.Domain), domain) {
+			c.Records = append(c.Records, info)
+		}
+	}
+	return &c
+}
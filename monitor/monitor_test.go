@@ -0,0 +1,208 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package monitor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	filing "github.com/houseme/icp-filing"
+)
+
+// fakeClock is a Clock moved by Advance
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.c }
+func (t *fakeTimer) Stop() bool          { return true }
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{at: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- c.now
+	} else {
+		c.timers = append(c.timers, t)
+	}
+	return t
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+		} else {
+			t.c <- c.now
+		}
+	}
+	c.timers = pending
+}
+
+// snapshotter answers the queued snapshots or errors of each domain
+type snapshotter struct {
+	mu      sync.Mutex
+	answers map[string][]any
+	calls   chan string
+}
+
+func (s *snapshotter) Snapshot(_ context.Context, query string, t filing.ServiceType) (*filing.Snapshot, error) {
+	s.mu.Lock()
+	answer := s.answers[query][0]
+	s.answers[query] = s.answers[query][1:]
+	s.mu.Unlock()
+	defer func() { s.calls <- query }()
+	if err, ok := answer.(error); ok {
+		return nil, err
+	}
+	return &filing.Snapshot{Query: query, ServiceType: t, Records: answer.([]*filing.DomainInfo)}, nil
+}
+
+func TestMonitor(t *testing.T) {
+	var (
+		baidu = &filing.DomainInfo{Domain: "baidu.com", UnitName: "北京百度网讯科技有限公司", ServiceLicence: "京ICP证030173号-1"}
+		moved = &filing.DomainInfo{Domain: "baidu.com", UnitName: "北京百度网讯科技有限公司", ServiceLicence: "京ICP证030173号-2"}
+		other = &filing.DomainInfo{Domain: "baidu.com.cn", UnitName: "其他公司"}
+		qq    = &filing.DomainInfo{Domain: "qq.com", UnitName: "深圳市腾讯计算机系统有限公司"}
+		down  = errors.New("upstream down")
+	)
+	s := &snapshotter{calls: make(chan string, 10), answers: map[string][]any{
+		"baidu.com": {
+			[]*filing.DomainInfo{baidu, other},
+			[]*filing.DomainInfo{moved, other},
+			down,
+			[]*filing.DomainInfo{other},
+		},
+		"qq.com": {[]*filing.DomainInfo{qq}},
+	}}
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}
+	events := make(chan Event, 10)
+	m := New(s, WithClock(clock), WithChannel(events))
+
+	if _, err := m.Add("baidu.com", 0, nil); !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("Add(interval 0) error = %v, want ErrInvalidInterval", err)
+	}
+	if _, err := m.Add("localhost", time.Hour, nil); !errors.Is(err, filing.ErrInvalidDomain) {
+		t.Errorf("Add(localhost) error = %v, want ErrInvalidDomain", err)
+	}
+	if d, err := m.Add("https://www.baidu.com/index.html", time.Hour, nil); err != nil || d != "baidu.com" {
+		t.Fatalf("Add() = %q, %v", d, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- m.Run(ctx) }()
+
+	wait := func(domain string) {
+		t.Helper()
+		select {
+		case got := <-s.calls:
+			if got != domain {
+				t.Fatalf("checked %s, want %s", got, domain)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s is not checked", domain)
+		}
+	}
+	next := func() Event {
+		t.Helper()
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event")
+		}
+		return Event{}
+	}
+
+	// the first check is the baseline, the similar domain is dropped
+	wait("baidu.com")
+	if err := m.Run(ctx); !errors.Is(err, ErrRunning) {
+		t.Errorf("Run() twice error = %v, want ErrRunning", err)
+	}
+
+	// a snapshot from a store is diffed on the first check
+	if _, err := m.Add("qq.com", 24*time.Hour, &filing.Snapshot{Records: []*filing.DomainInfo{}}); err != nil {
+		t.Fatal(err)
+	}
+	wait("qq.com")
+	if e := next(); e.Type != FilingAdded || e.Domain != "qq.com" || e.Change.New != qq {
+		t.Errorf("event = %+v, want qq.com added", e)
+	}
+
+	clock.Advance(time.Hour)
+	wait("baidu.com")
+	e := next()
+	if e.Type != FilingChanged || !e.Change.LicenceChanged() || len(e.Snapshot.Records) != 1 || !e.Time.Equal(clock.Now()) {
+		t.Errorf("event = %+v, want the licence of baidu.com changed", e)
+	}
+	if last := m.Last("baidu.com"); last == nil || last.Records[0] != moved {
+		t.Errorf("Last() = %v", last)
+	}
+
+	clock.Advance(time.Hour)
+	wait("baidu.com")
+	if e := next(); e.Type != CheckFailed || !errors.Is(e.Err, down) {
+		t.Errorf("event = %+v, want the check failed", e)
+	}
+
+	clock.Advance(time.Hour)
+	wait("baidu.com")
+	if e := next(); e.Type != FilingRemoved || !e.Change.Cancelled() || e.Change.Old != moved {
+		t.Errorf("event = %+v, want baidu.com removed", e)
+	}
+
+	if !m.Remove("qq.com") || m.Remove("qq.com") {
+		t.Error("Remove(qq.com) twice, want true then false")
+	}
+	if got := m.Domains(); len(got) != 1 || got[0] != "baidu.com" {
+		t.Errorf("Domains() = %v", got)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want context.Canceled", err)
+	}
+	select {
+	case e := <-events:
+		t.Errorf("unexpected event %+v", e)
+	default:
+	}
+}
@@ -0,0 +1,173 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NatureKind is the kind of a filing entity
+type NatureKind int
+
+// Natures of filing entities
+const (
+	NatureUnknown          NatureKind = iota
+	NatureEnterprise                  // 企业
+	NatureIndividual                  // 个人
+	NatureInstitution                 // 事业单位
+	NatureGovernment                  // 政府机关
+	NatureSocialGroup                 // 社会团体
+	NaturePrivateNonProfit            // 民办非企业单位
+	NatureFoundation                  // 基金会
+	NatureLawFirm                     // 律师执业机构
+	NatureMilitary                    // 军队
+)
+
+// natureNames are the upstream and English names of the natures
+var natureNames = []struct {
+	kind    NatureKind
+	chinese string
+	english string
+}{
+	{NatureEnterprise, "企业", "enterprise"},
+	{NatureIndividual, "个人", "individual"},
+	{NatureInstitution, "事业单位", "institution"},
+	{NatureGovernment, "政府机关", "government"},
+	{NatureSocialGroup, "社会团体", "social_group"},
+	{NaturePrivateNonProfit, "民办非企业单位", "private_non_profit"},
+	{NatureFoundation, "基金会", "foundation"},
+	{NatureLawFirm, "律师执业机构", "law_firm"},
+	{NatureMilitary, "军队", "military"},
+}
+
+// Nature is the nature of a filing entity, Raw keeps the text it was parsed
+// from so an unknown nature is not lost
+type Nature struct {
+	Kind NatureKind
+	Raw  string
+}
+
+// ParseNature parse the upstream NatureName or an English name, the spaces
+// are ignored. An unknown text has the kind NatureUnknown.
+func ParseNature(s string) Nature {
+	n := Nature{Raw: strings.TrimSpace(s)}
+	key := compact(s)
+	for _, name := range natureNames {
+		if key == name.chinese || strings.EqualFold(key, name.english) {
+			n.Kind = name.kind
+			break
+		}
+	}
+	return n
+}
+
+// String return the English name of n, or its raw text when it is unknown
+func (n Nature) String() string {
+	for _, name := range natureNames {
+		if n.Kind == name.kind {
+			return name.english
+		}
+	}
+	return n.Raw
+}
+
+// MarshalText implements encoding.TextMarshaler, see String
+func (n Nature) MarshalText() ([]byte, error) {
+	return []byte(n.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, see ParseNature
+func (n *Nature) UnmarshalText(text []byte) error {
+	*n = ParseNature(string(text))
+	return nil
+}
+
+// AccessKind tells whether the access to a service is restricted
+type AccessKind int
+
+// Access restrictions
+const (
+	AccessUnknown      AccessKind = iota
+	AccessRestricted              // 是
+	AccessUnrestricted            // 否
+)
+
+// AccessRestriction is the access restriction of a service, Raw keeps the
+// text it was parsed from so an unknown value is not lost
+type AccessRestriction struct {
+	Kind AccessKind
+	Raw  string
+}
+
+// ParseAccessRestriction parse the upstream LimitAccess, 是 or 否, or an
+// English name. An unknown text has the kind AccessUnknown.
+func ParseAccessRestriction(s string) AccessRestriction {
+	a := AccessRestriction{Raw: strings.TrimSpace(s)}
+	switch strings.ToLower(compact(s)) {
+	case "是", "restricted", "yes", "true":
+		a.Kind = AccessRestricted
+	case "否", "unrestricted", "no", "false":
+		a.Kind = AccessUnrestricted
+	}
+	return a
+}
+
+// String return the English name of a, or its raw text when it is unknown
+func (a AccessRestriction) String() string {
+	switch a.Kind {
+	case AccessRestricted:
+		return "restricted"
+	case AccessUnrestricted:
+		return "unrestricted"
+	default:
+		return a.Raw
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler, see String
+func (a AccessRestriction) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, see ParseAccessRestriction
+func (a *AccessRestriction) UnmarshalText(text []byte) error {
+	*a = ParseAccessRestriction(string(text))
+	return nil
+}
+
+// Nature return the parsed NatureName
+func (r *DomainInfo) Nature() Nature {
+	return ParseNature(r.NatureName)
+}
+
+// Access return the parsed LimitAccess
+func (r *DomainInfo) Access() AccessRestriction {
+	return ParseAccessRestriction(r.LimitAccess)
+}
+
+// compact drop the spaces of s, full-width ones included
+func compact(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,90 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseNature(t *testing.T) {
+	tests := []struct {
+		in       string
+		want     NatureKind
+		wantText string
+	}{
+		{in: "企业", want: NatureEnterprise, wantText: "enterprise"},
+		{in: " 个 人　", want: NatureIndividual, wantText: "individual"},
+		{in: "事业单位", want: NatureInstitution, wantText: "institution"},
+		{in: "政府机关", want: NatureGovernment, wantText: "government"},
+		{in: "社会团体", want: NatureSocialGroup, wantText: "social_group"},
+		{in: "民办非企业单位", want: NaturePrivateNonProfit, wantText: "private_non_profit"},
+		{in: "Enterprise", want: NatureEnterprise, wantText: "enterprise"},
+		{in: " 宗教团体 ", want: NatureUnknown, wantText: "宗教团体"},
+		{in: "", want: NatureUnknown, wantText: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got := ParseNature(tt.in)
+			if got.Kind != tt.want || got.String() != tt.wantText {
+				t.Errorf("ParseNature() = %v %q, want %v %q", got.Kind, got, tt.want, tt.wantText)
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var back Nature
+			if err = json.Unmarshal(b, &back); err != nil || back.Kind != got.Kind || back.String() != got.String() {
+				t.Errorf("json round trip of %s = %+v, %v, want %+v", b, back, err, got)
+			}
+		})
+	}
+	if got := (&DomainInfo{NatureName: "个人 "}).Nature(); got.Kind != NatureIndividual {
+		t.Errorf("DomainInfo.Nature() = %+v, want individual", got)
+	}
+}
+
+func TestParseAccessRestriction(t *testing.T) {
+	tests := []struct {
+		in       string
+		want     AccessKind
+		wantText string
+	}{
+		{in: "是", want: AccessRestricted, wantText: "restricted"},
+		{in: " 否 ", want: AccessUnrestricted, wantText: "unrestricted"},
+		{in: "Restricted", want: AccessRestricted, wantText: "restricted"},
+		{in: "部分", want: AccessUnknown, wantText: "部分"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got := ParseAccessRestriction(tt.in)
+			if got.Kind != tt.want || got.String() != tt.wantText {
+				t.Errorf("ParseAccessRestriction() = %v %q, want %v %q", got.Kind, got, tt.want, tt.wantText)
+			}
+			var back AccessRestriction
+			b, _ := json.Marshal(got)
+			if err := json.Unmarshal(b, &back); err != nil || back.Kind != got.Kind {
+				t.Errorf("json round trip of %s = %+v, %v", b, back, err)
+			}
+		})
+	}
+	if got := (&DomainInfo{LimitAccess: "否"}).Access(); got.Kind != AccessUnrestricted {
+		t.Errorf("DomainInfo.Access() = %+v, want unrestricted", got)
+	}
+}
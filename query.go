@@ -0,0 +1,98 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Pagination of a query
+const (
+	// DefaultPageNum is the page queried when PageNum is zero
+	DefaultPageNum = 1
+
+	// DefaultPageSize is the page size queried when PageSize is zero
+	DefaultPageSize = 10
+
+	// MaxPageSize is the largest page size the upstream accepts
+	MaxPageSize = 40
+)
+
+// ErrInvalidPage is returned for a negative page or a page size above MaxPageSize
+var ErrInvalidPage = errors.New("invalid page")
+
+// NewQuery return the request of the filings of unit, a domain, a company or
+// an APP name, for the first page of websites
+func NewQuery(unit string) *QueryRequest {
+	return &QueryRequest{
+		UnitName:    unit,
+		ServiceType: ServiceWebsite,
+		PageNum:     DefaultPageNum,
+		PageSize:    DefaultPageSize,
+	}
+}
+
+// Page set the page number, starting at 1
+func (r *QueryRequest) Page(n int) *QueryRequest {
+	r.PageNum = n
+	return r
+}
+
+// Size set the page size, at most MaxPageSize
+func (r *QueryRequest) Size(n int) *QueryRequest {
+	r.PageSize = n
+	return r
+}
+
+// Type set the service type
+func (r *QueryRequest) Type(t ServiceType) *QueryRequest {
+	r.ServiceType = t
+	return r
+}
+
+// Validate check the service type and the pagination, zero values are
+// valid and replaced by their defaults when the request is sent
+func (r *QueryRequest) Validate() error {
+	if r.ServiceType != 0 && !r.ServiceType.Valid() {
+		return fmt.Errorf("%w: %d", ErrInvalidServiceType, int(r.ServiceType))
+	}
+	if r.PageNum < 0 {
+		return fmt.Errorf("%w: page number %d", ErrInvalidPage, r.PageNum)
+	}
+	if r.PageSize < 0 || r.PageSize > MaxPageSize {
+		return fmt.Errorf("%w: page size %d, the limit is %d", ErrInvalidPage, r.PageSize, MaxPageSize)
+	}
+	return nil
+}
+
+// withDefaults return a copy of r with the zero values replaced by their defaults
+func (r *QueryRequest) withDefaults() *QueryRequest {
+	c := *r
+	if c.ServiceType == 0 {
+		c.ServiceType = ServiceWebsite
+	}
+	if c.PageNum == 0 {
+		c.PageNum = DefaultPageNum
+	}
+	if c.PageSize == 0 {
+		c.PageSize = DefaultPageSize
+	}
+	return &c
+}
@@ -0,0 +1,94 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewQuery(t *testing.T) {
+	req := NewQuery("北京百度网讯科技有限公司").Page(2).Size(40).Type(ServiceApp)
+	want := QueryRequest{UnitName: "北京百度网讯科技有限公司", ServiceType: ServiceApp, PageNum: 2, PageSize: 40}
+	if *req != want {
+		t.Errorf("NewQuery() = %+v, want %+v", *req, want)
+	}
+
+	// the upstream expects the pagination as strings
+	b, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"pageNum":"2","pageSize":"40","unitName":"北京百度网讯科技有限公司","serviceType":6}`; string(b) != want {
+		t.Errorf("json.Marshal() = %s, want %s", b, want)
+	}
+	var got QueryRequest
+	if err = json.Unmarshal(b, &got); err != nil || got != *req {
+		t.Errorf("json.Unmarshal() = %+v, %v, want %+v", got, err, *req)
+	}
+}
+
+func TestQueryRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *QueryRequest
+		wantErr error
+	}{
+		{name: "zero", req: &QueryRequest{UnitName: "baidu.com"}},
+		{name: "max page size", req: NewQuery("baidu.com").Size(MaxPageSize)},
+		{name: "page size too large", req: NewQuery("baidu.com").Size(MaxPageSize + 1), wantErr: ErrInvalidPage},
+		{name: "negative page", req: NewQuery("baidu.com").Page(-1), wantErr: ErrInvalidPage},
+		{name: "negative page size", req: NewQuery("baidu.com").Size(-1), wantErr: ErrInvalidPage},
+		{name: "service type", req: NewQuery("baidu.com").Type(5), wantErr: ErrInvalidServiceType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFilling_QueryDefaults(t *testing.T) {
+	ctx := context.Background()
+	var body []byte
+	f := New(ctx, WithDoer(queryDoer(func(req *http.Request) (int, string, string) {
+		body, _ = io.ReadAll(req.Body)
+		return http.StatusOK, "application/json", testQueryBody
+	})))
+	req := &QueryRequest{UnitName: "baidu.com"}
+	if _, err := f.DomainFilling(ctx, req); err != nil {
+		t.Fatalf("DomainFilling() error = %v", err)
+	}
+	if want := `{"pageNum":"1","pageSize":"10","unitName":"baidu.com","serviceType":1}`; strings.TrimSpace(string(body)) != want {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+	if req.PageNum != 0 || req.PageSize != 0 || req.ServiceType != 0 {
+		t.Errorf("DomainFilling() modified the request defaults: %+v", req)
+	}
+	if _, err := f.DomainFilling(ctx, NewQuery("baidu.com").Size(100)); !errors.Is(err, ErrInvalidPage) {
+		t.Errorf("DomainFilling() error = %v, want ErrInvalidPage", err)
+	}
+}
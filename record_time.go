@@ -0,0 +1,92 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Beijing is the time zone of the upstream dates, China has no daylight saving time
+var Beijing = time.FixedZone("CST", 8*60*60)
+
+// ErrInvalidTime is returned for a date in an unknown format
+var ErrInvalidTime = errors.New("invalid time")
+
+// recordTimeLayouts are the layouts emitted by the upstream, the first one is the usual one
+var recordTimeLayouts = []string{
+	time.DateTime,
+	"2006-01-02 15:04",
+	time.DateOnly,
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+	"2006年01月02日",
+}
+
+// now return the current time, tests replace it
+var now = time.Now
+
+// ParseRecordTime parse a date of the upstream in Beijing time, unless it
+// carries its own offset. Unix timestamps in milliseconds are accepted too.
+func ParseRecordTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, fmt.Errorf("%w: empty", ErrInvalidTime)
+	}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil && len(s) == 13 {
+		return time.UnixMilli(ms).In(Beijing), nil
+	}
+	for _, layout := range recordTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, Beijing); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTime, s)
+}
+
+// UpdatedAt return UpdateRecordTime, the last update of the filing
+func (r *DomainInfo) UpdatedAt() (time.Time, error) {
+	return ParseRecordTime(r.UpdateRecordTime)
+}
+
+// RecordAge return the time elapsed since the last update of the filing
+func (r *DomainInfo) RecordAge() (time.Duration, error) {
+	t, err := r.UpdatedAt()
+	if err != nil {
+		return 0, err
+	}
+	return now().Sub(t), nil
+}
+
+// UpdatedWithin reports whether the filing was updated in the last d, a
+// filing without a valid UpdateRecordTime is not
+func (r *DomainInfo) UpdatedWithin(d time.Duration) bool {
+	age, err := r.RecordAge()
+	return err == nil && age <= d
+}
+
+// DateTime return Date parsed in Beijing time
+func (r *QueryResp) DateTime() (time.Time, error) {
+	return ParseRecordTime(r.Date)
+}
@@ -0,0 +1,75 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseRecordTime(t *testing.T) {
+	want := time.Date(2023, 6, 1, 10, 0, 0, 0, Beijing)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "2023-06-01 10:00:00", want: want},
+		{in: " 2023-06-01 10:00 ", want: want},
+		{in: "2023/06/01 10:00:00", want: want},
+		{in: "2023-06-01T10:00:00", want: want},
+		{in: "2023-06-01T02:00:00Z", want: want},
+		{in: "1685584800000", want: want},
+		{in: "2023-06-01", want: time.Date(2023, 6, 1, 0, 0, 0, 0, Beijing)},
+		{in: "2023年06月01日", want: time.Date(2023, 6, 1, 0, 0, 0, 0, Beijing)},
+		{in: "", wantErr: true},
+		{in: "yesterday", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseRecordTime(tt.in)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidTime)) {
+				t.Fatalf("ParseRecordTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseRecordTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDomainInfo_RecordAge(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	now = func() time.Time { return time.Date(2023, 7, 1, 10, 0, 0, 0, Beijing) }
+
+	info := &DomainInfo{UpdateRecordTime: "2023-06-11 10:00:00"}
+	if age, err := info.RecordAge(); err != nil || age != 20*24*time.Hour {
+		t.Errorf("RecordAge() = %v, %v, want 480h", age, err)
+	}
+	if !info.UpdatedWithin(30*24*time.Hour) || info.UpdatedWithin(7*24*time.Hour) {
+		t.Errorf("UpdatedWithin() is wrong for an age of 20 days")
+	}
+	if (&DomainInfo{}).UpdatedWithin(30 * 24 * time.Hour) {
+		t.Errorf("UpdatedWithin() = true without UpdateRecordTime")
+	}
+	if _, err := (&QueryResp{Date: "2023-06-01"}).DateTime(); err != nil {
+		t.Errorf("DateTime() error = %v", err)
+	}
+}
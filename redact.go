@@ -0,0 +1,109 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// redacted replaces secret and personal values in logs and String output
+const redacted = "******"
+
+// sensitiveKeys are the lower-cased attribute keys whose value is never logged
+var sensitiveKeys = map[string]struct{}{
+	"token":      {},
+	"sign":       {},
+	"authkey":    {},
+	"leadername": {},
+}
+
+// redact return redacted for a non-empty value
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redacted
+}
+
+// isSensitive reports whether the attribute key must be redacted
+func isSensitive(key string) bool {
+	_, ok := sensitiveKeys[strings.ToLower(key)]
+	return ok
+}
+
+// redactHandler masks sensitive attributes before handing records to the next handler
+type redactHandler struct {
+	next slog.Handler
+}
+
+// newRedactHandler wrap next, a handler is never wrapped twice
+func newRedactHandler(next slog.Handler) slog.Handler {
+	if _, ok := next.(*redactHandler); ok {
+		return next
+	}
+	return &redactHandler{next: next}
+}
+
+// Enabled implements slog.Handler
+func (h *redactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler
+func (h *redactHandler) Handle(ctx context.Context, r slog.Record) error {
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(redactAttr(a))
+		return true
+	})
+	return h.next.Handle(ctx, out)
+}
+
+// WithAttrs implements slog.Handler
+func (h *redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	masked := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		masked[i] = redactAttr(a)
+	}
+	return &redactHandler{next: h.next.WithAttrs(masked)}
+}
+
+// WithGroup implements slog.Handler
+func (h *redactHandler) WithGroup(name string) slog.Handler {
+	return &redactHandler{next: h.next.WithGroup(name)}
+}
+
+// redactAttr mask a, groups and slog.LogValuer values are resolved first
+func redactAttr(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		group := a.Value.Group()
+		masked := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			masked[i] = redactAttr(ga)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(masked...)}
+	}
+	if isSensitive(a.Key) {
+		return slog.String(a.Key, redact(a.Value.String()))
+	}
+	return a
+}
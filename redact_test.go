@@ -0,0 +1,128 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRedactHandler(t *testing.T) {
+	var (
+		ctx = context.Background()
+		buf = new(bytes.Buffer)
+		f   = New(ctx, WithSlogHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	)
+	f.logger.With("Token", "live-token").DebugContext(ctx, "redact",
+		slog.String("sign", "live-sign"),
+		slog.Group("headers", slog.String("Token", "live-token")),
+		slog.Any("auth", &AuthorizeRequest{AuthKey: "live-auth-key", Timestamp: "1700000000"}),
+		slog.Any("record", &DomainInfo{Domain: "baidu.com", LeaderName: "张三"}),
+	)
+
+	out := buf.String()
+	for _, secret := range []string{"live-token", "live-sign", "live-auth-key", "张三"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log output contains %q: %s", secret, out)
+		}
+	}
+	for _, kept := range []string{"baidu.com", "1700000000", redacted} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("log output lacks %q: %s", kept, out)
+		}
+	}
+	if in := (&ParamInput{AuthorizeRequest: &AuthorizeRequest{AuthKey: "live-auth-key"}}).String(); strings.Contains(in, "live-auth-key") {
+		t.Errorf("ParamInput.String() = %s", in)
+	}
+}
+
+func TestRedact_LeaderName(t *testing.T) {
+	var (
+		info   = &DomainInfo{Domain: "baidu.com", LeaderName: "李四"}
+		params = &QueryParams{Total: 1, List: []*DomainInfo{info}}
+		resp   = &QueryResponse{Code: 200, Success: true, Params: params}
+		buf    = new(bytes.Buffer)
+	)
+	// without the redact handler, the LogValue methods alone mask the leader
+	slog.New(slog.NewJSONHandler(buf, nil)).Info("query", slog.Any("resp", resp), slog.Any("params", params))
+	tests := map[string]string{
+		"log":                          buf.String(),
+		"DomainInfo.String":            info.String(),
+		"QueryParams.String":           params.String(),
+		"QueryParams.ParamsListString": params.ParamsListString(),
+		"QueryResponse.String":         resp.String(),
+		"Coverage.String":              (&Coverage{Record: info}).String(),
+	}
+	for name, out := range tests {
+		if strings.Contains(out, "李四") || !strings.Contains(out, "baidu.com") || !strings.Contains(out, redacted) {
+			t.Errorf("%s = %s, want the leader name redacted", name, out)
+		}
+	}
+	if info.LeaderName != "李四" {
+		t.Errorf("LeaderName = %q, the record is modified", info.LeaderName)
+	}
+}
+
+func TestLogValue_Nil(t *testing.T) {
+	tests := map[string]slog.LogValuer{
+		"AuthorizeRequest": (*AuthorizeRequest)(nil),
+		"DomainInfo":       (*DomainInfo)(nil),
+		"QueryParams":      (*QueryParams)(nil),
+		"QueryResponse":    (*QueryResponse)(nil),
+	}
+	for name, v := range tests {
+		buf := new(bytes.Buffer)
+		slog.New(slog.NewJSONHandler(buf, nil)).Info("nil", slog.Any("value", v))
+		if out := buf.String(); !strings.Contains(out, `"value":null`) {
+			t.Errorf("%s.LogValue() logged %s, want null", name, out)
+		}
+	}
+}
+
+func TestFilling_NoStdout(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	ctx := context.Background()
+	f := New(ctx, WithDoer(queryDoer(func(*http.Request) (int, string, string) {
+		return http.StatusOK, "application/json", testQueryBody
+	})))
+	_, err = f.DomainFilling(ctx, &QueryRequest{UnitName: "baidu.com"})
+	_ = w.Close()
+	os.Stdout = stdout
+	if err != nil {
+		t.Fatalf("DomainFilling() error = %v", err)
+	}
+	if out, _ := io.ReadAll(r); len(out) > 0 {
+		t.Errorf("DomainFilling() wrote to stdout: %q", out)
+	}
+}
@@ -0,0 +1,199 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	filing "github.com/houseme/icp-filing"
+	filingv1 "github.com/houseme/icp-filing/api/filing/v1"
+	"github.com/houseme/icp-filing/tld"
+)
+
+// grpcServer implements filingv1.FilingServiceServer with a Server
+type grpcServer struct {
+	filingv1.UnimplementedFilingServiceServer
+	s *Server
+}
+
+// NewGRPC return the gRPC FilingService answering with f, it takes the same
+// options as New. Register it with filingv1.RegisterFilingServiceServer.
+func NewGRPC(f *filing.Filling, opts ...Option) filingv1.FilingServiceServer {
+	return &grpcServer{s: New(f, opts...)}
+}
+
+// Lookup implements filingv1.FilingServiceServer
+func (g *grpcServer) Lookup(ctx context.Context, in *filingv1.LookupRequest) (*filingv1.LookupResponse, error) {
+	r, params, err := g.s.lookup(ctx, queryOf(in))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return lookupResponse(r, params), nil
+}
+
+// BatchLookup implements filingv1.FilingServiceServer, the responses are
+// sent in completion order with the index of their item
+func (g *grpcServer) BatchLookup(in *filingv1.BatchLookupRequest, stream filingv1.FilingService_BatchLookupServer) error {
+	items := in.GetItems()
+	switch {
+	case len(items) == 0:
+		return status.Error(codes.InvalidArgument, "items is empty")
+	case len(items) > g.s.maxBatch:
+		return status.Error(codes.InvalidArgument, "too many items, the limit is "+strconv.Itoa(g.s.maxBatch))
+	}
+	queries := make([]*Query, len(items))
+	for i, item := range items {
+		queries[i] = queryOf(item)
+		if _, err := queries[i].request(); err != nil {
+			return status.Error(codes.InvalidArgument, "item "+strconv.Itoa(i)+": "+err.Error())
+		}
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	var (
+		mu      sync.Mutex
+		sendErr error
+	)
+	g.s.each(ctx, queries, func(i int, r *Result, params *filing.QueryParams, err error) {
+		resp := &filingv1.BatchLookupResponse{Index: int32(i)}
+		if err != nil {
+			g.s.logger.WarnContext(ctx, "batch item failed", "index", i, "error", err)
+			resp.Result = &filingv1.BatchLookupResponse_Error{Error: err.Error()}
+		} else {
+			resp.Result = &filingv1.BatchLookupResponse_Response{Response: lookupResponse(r, params)}
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if sendErr != nil {
+			return
+		}
+		if sendErr = stream.Send(resp); sendErr != nil {
+			cancel()
+		}
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	return grpcError(stream.Context().Err())
+}
+
+// ParseDomain implements filingv1.FilingServiceServer
+func (g *grpcServer) ParseDomain(ctx context.Context, in *filingv1.ParseDomainRequest) (*filingv1.DomainTLD, error) {
+	if in.GetUrl() == "" {
+		return nil, status.Error(codes.InvalidArgument, "url is required")
+	}
+	if in.GetLevel() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid level")
+	}
+	resp, err := tld.GetTLD(ctx, tld.Hostname(in.GetUrl()), int(in.GetLevel()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &filingv1.DomainTLD{
+		Link:      in.GetUrl(),
+		Subdomain: resp.SubDomain,
+		Domain:    resp.Domain,
+		Tld:       resp.Tld,
+		Label:     int32(resp.Label),
+	}, nil
+}
+
+// queryOf return the Query of a lookup request
+func queryOf(in *filingv1.LookupRequest) *Query {
+	q := &Query{Domain: in.GetDomain(), Unit: in.GetUnit(), Page: int(in.GetPage()), Size: int(in.GetSize())}
+	if in.GetServiceType() != filingv1.ServiceType_SERVICE_TYPE_UNSPECIFIED {
+		q.Type = strconv.Itoa(int(in.GetServiceType()))
+	}
+	return q
+}
+
+// grpcError return the status error of a lookup error, nil for nil
+func grpcError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, errBadRequest):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		// upstream errors and challenges are both worth a later retry
+		return status.Error(codes.Unavailable, err.Error())
+	}
+}
+
+// lookupResponse convert a lookup result, the list of the page is replaced
+// by the matching records
+func lookupResponse(r *Result, params *filing.QueryParams) *filingv1.LookupResponse {
+	p := &filingv1.QueryParams{
+		EndRow:          int32(params.EndRow),
+		FirstPage:       int32(params.FirstPage),
+		HasNextPage:     params.HasNextPage,
+		HasPreviousPage: params.HasPreviousPage,
+		IsFirstPage:     params.IsFirstPage,
+		IsLastPage:      params.IsLastPage,
+		LastPage:        int32(params.LastPage),
+		List:            make([]*filingv1.DomainInfo, 0, len(r.Records)),
+		NavigatePages:   int32(params.NavigatePages),
+		NextPage:        int32(params.NextPage),
+		PageNum:         int32(params.PageNum),
+		PageSize:        int32(params.PageSize),
+		Pages:           int32(params.Pages),
+		PrePage:         int32(params.PrePage),
+		Size:            int32(params.Size),
+		StartRow:        int32(params.StartRow),
+		Total:           int32(params.Total),
+	}
+	for _, n := range params.NavigatePageNums {
+		p.NavigatepageNums = append(p.NavigatepageNums, int32(n))
+	}
+	for _, info := range r.Records {
+		p.List = append(p.List, domainInfo(info))
+	}
+	return &filingv1.LookupResponse{Query: r.Query, Domain: r.Domain, Filed: r.Filed, Params: p}
+}
+
+// domainInfo convert a filing record
+func domainInfo(info *filing.DomainInfo) *filingv1.DomainInfo {
+	return &filingv1.DomainInfo{
+		ContentTypeName:  info.ContentTypeName,
+		Domain:           info.Domain,
+		DomainId:         info.DomainID,
+		HomeUrl:          info.HomeURL,
+		LeaderName:       info.LeaderName,
+		LimitAccess:      info.LimitAccess,
+		MainId:           info.MainID,
+		MainLicence:      info.MainLicence,
+		NatureName:       info.NatureName,
+		ServiceId:        info.ServiceID,
+		ServiceLicence:   info.ServiceLicence,
+		ServiceName:      info.ServiceName,
+		UnitName:         info.UnitName,
+		UpdateRecordTime: info.UpdateRecordTime,
+	}
+}
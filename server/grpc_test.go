@@ -0,0 +1,159 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sort"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	filingv1 "github.com/houseme/icp-filing/api/filing/v1"
+)
+
+// newTestClient return a client of the gRPC service of newTestFilling, served over bufconn
+func newTestClient(t *testing.T) filingv1.FilingServiceClient {
+	t.Helper()
+	f, _ := newTestFilling(t)
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	filingv1.RegisterFilingServiceServer(srv, NewGRPC(f, WithMaxBatch(3)))
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return filingv1.NewFilingServiceClient(conn)
+}
+
+func TestGRPC_Lookup(t *testing.T) {
+	client := newTestClient(t)
+	tests := []struct {
+		name      string
+		in        *filingv1.LookupRequest
+		wantCode  codes.Code
+		wantFiled bool
+		wantList  int
+		wantPage  int32
+	}{
+		{name: "domain", in: &filingv1.LookupRequest{Query: &filingv1.LookupRequest_Domain{Domain: "https://www.baidu.com/"}},
+			wantFiled: true, wantList: 1},
+		{name: "unit", in: &filingv1.LookupRequest{Query: &filingv1.LookupRequest_Unit{Unit: "北京百度网讯科技有限公司"},
+			ServiceType: filingv1.ServiceType_SERVICE_TYPE_APP}, wantFiled: true, wantList: 2},
+		{name: "domain with type and page", in: &filingv1.LookupRequest{Query: &filingv1.LookupRequest_Domain{Domain: "baidu.com"},
+			ServiceType: filingv1.ServiceType_SERVICE_TYPE_APP, Page: 3, Size: 5}, wantFiled: true, wantList: 1, wantPage: 3},
+		{name: "not filed", in: &filingv1.LookupRequest{Query: &filingv1.LookupRequest_Domain{Domain: "example.com"}}},
+		{name: "missing query", in: &filingv1.LookupRequest{}, wantCode: codes.InvalidArgument},
+		{name: "unknown type", in: &filingv1.LookupRequest{Query: &filingv1.LookupRequest_Unit{Unit: "x"}, ServiceType: 3},
+			wantCode: codes.InvalidArgument},
+		{name: "upstream error", in: &filingv1.LookupRequest{Query: &filingv1.LookupRequest_Domain{Domain: "broken.com"}},
+			wantCode: codes.Unavailable},
+		{name: "challenge", in: &filingv1.LookupRequest{Query: &filingv1.LookupRequest_Domain{Domain: "waf.com"}},
+			wantCode: codes.Unavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.Lookup(context.Background(), tt.in)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("Lookup() code = %v, want %v, error %v", got, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if resp.GetFiled() != tt.wantFiled || len(resp.GetParams().GetList()) != tt.wantList {
+				t.Errorf("Lookup() = %v, want filed %v with %d records", resp, tt.wantFiled, tt.wantList)
+			}
+			if tt.wantPage != 0 && resp.GetParams().GetPageNum() != tt.wantPage {
+				t.Errorf("Lookup() page = %d, want %d", resp.GetParams().GetPageNum(), tt.wantPage)
+			}
+		})
+	}
+}
+
+func TestGRPC_BatchLookup(t *testing.T) {
+	client := newTestClient(t)
+	stream, err := client.BatchLookup(context.Background(), &filingv1.BatchLookupRequest{Items: []*filingv1.LookupRequest{
+		{Query: &filingv1.LookupRequest_Domain{Domain: "baidu.com"}},
+		{Query: &filingv1.LookupRequest_Domain{Domain: "broken.com"}},
+		{Query: &filingv1.LookupRequest_Unit{Unit: "北京百度网讯科技有限公司"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []*filingv1.BatchLookupResponse
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		got = append(got, resp)
+	}
+	if len(got) != 3 {
+		t.Fatalf("responses = %d, want 3", len(got))
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i].GetIndex() < got[j].GetIndex() })
+	if !got[0].GetResponse().GetFiled() || got[1].GetError() == "" || len(got[2].GetResponse().GetParams().GetList()) != 2 {
+		t.Errorf("BatchLookup() = %v", got)
+	}
+
+	stream, err = client.BatchLookup(context.Background(), &filingv1.BatchLookupRequest{Items: make([]*filingv1.LookupRequest, 4)})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("BatchLookup() of 4 items error = %v, want InvalidArgument", err)
+	}
+}
+
+func TestGRPC_ParseDomain(t *testing.T) {
+	client := newTestClient(t)
+	resp, err := client.ParseDomain(context.Background(), &filingv1.ParseDomainRequest{Url: "https://mp.weixin.qq.com/s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetDomain() != "qq.com" || resp.GetTld() != "com" {
+		t.Errorf("ParseDomain() = %v, want qq.com", resp)
+	}
+	if _, err = client.ParseDomain(context.Background(), &filingv1.ParseDomainRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ParseDomain() without url error = %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,450 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+// Package server exposes a shared Filling as an HTTP/JSON API.
+//
+//	GET  /v1/filings?domain=baidu.com
+//	GET  /v1/filings?unit=北京百度网讯科技有限公司&type=app&page=1&size=10
+//	POST /v1/filings:batch {"items":[{"domain":"baidu.com"},{"unit":"...","type":"app"}]}
+//	GET  /v1/tld?url=https://www.baidu.com/&level=0
+//	GET  /healthz
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	filing "github.com/houseme/icp-filing"
+	"github.com/houseme/icp-filing/tld"
+	"github.com/houseme/icp-filing/utility/logger"
+)
+
+const (
+	// DefaultMaxBatch is the default number of items of a batch request
+	DefaultMaxBatch = 100
+
+	// DefaultWorkers is the default number of concurrent lookups of a batch request
+	DefaultWorkers = 4
+
+	// DefaultTimeout is the default timeout of one lookup
+	DefaultTimeout = 30 * time.Second
+
+	// maxBodySize is the size limit of a batch request body
+	maxBodySize = 1 << 20
+)
+
+// Server is the http.Handler of the API, it is safe for concurrent use
+type Server struct {
+	filling  *filing.Filling
+	logger   *slog.Logger
+	timeout  time.Duration
+	maxBatch int
+	workers  int
+}
+
+type options struct {
+	Logger   *slog.Logger
+	Timeout  time.Duration
+	MaxBatch int
+	Workers  int
+}
+
+// Option is the option of the server.
+type Option func(o *options)
+
+// WithLogger is the option for the logger of failed requests.
+func WithLogger(l *slog.Logger) Option {
+	return func(o *options) {
+		o.Logger = l
+	}
+}
+
+// WithTimeout is the option for the timeout of one lookup.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.Timeout = d
+	}
+}
+
+// WithMaxBatch is the option for the number of items of a batch request.
+func WithMaxBatch(n int) Option {
+	return func(o *options) {
+		o.MaxBatch = n
+	}
+}
+
+// WithWorkers is the option for the number of concurrent lookups of a batch request.
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		o.Workers = n
+	}
+}
+
+// New return a server answering with f, which should be configured with a
+// cache and a rate limiter as it is shared by every client.
+func New(f *filing.Filling, opts ...Option) *Server {
+	op := options{
+		Timeout:  DefaultTimeout,
+		MaxBatch: DefaultMaxBatch,
+		Workers:  DefaultWorkers,
+	}
+	for _, option := range opts {
+		option(&op)
+	}
+	if op.Logger == nil {
+		op.Logger = slog.New(logger.DiscardHandler)
+	}
+	if op.Timeout <= 0 {
+		op.Timeout = DefaultTimeout
+	}
+	if op.MaxBatch <= 0 {
+		op.MaxBatch = DefaultMaxBatch
+	}
+	if op.Workers <= 0 {
+		op.Workers = DefaultWorkers
+	}
+	return &Server{
+		filling:  f,
+		logger:   op.Logger,
+		timeout:  op.Timeout,
+		maxBatch: op.MaxBatch,
+		workers:  op.Workers,
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/v1/filings":
+		if allow(w, r, http.MethodGet) {
+			s.handleFilings(w, r)
+		}
+	case "/v1/filings:batch":
+		if allow(w, r, http.MethodPost) {
+			s.handleBatch(w, r)
+		}
+	case "/v1/tld":
+		if allow(w, r, http.MethodGet) {
+			s.handleTLD(w, r)
+		}
+	case "/healthz":
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// allow reports whether r uses method, it answers 405 otherwise
+func allow(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method || (method == http.MethodGet && r.Method == http.MethodHead) {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	return false
+}
+
+// Query is one lookup, by domain or by unit name
+type Query struct {
+	Domain string `json:"domain,omitempty"`
+	Unit   string `json:"unit,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Page   int    `json:"page,omitempty"`
+	Size   int    `json:"size,omitempty"`
+}
+
+// Result is the outcome of a Query, Total is the upstream total of a unit
+// query and the number of matching records of a domain query
+type Result struct {
+	Query   string               `json:"query"`
+	Domain  string               `json:"domain,omitempty"`
+	Filed   bool                 `json:"filed"`
+	Total   int                  `json:"total"`
+	Records []*filing.DomainInfo `json:"records"`
+	Verdict *filing.Verdict      `json:"verdict,omitempty"`
+	Error   string               `json:"error,omitempty"`
+}
+
+// errBadRequest marks the errors of invalid queries
+var errBadRequest = errors.New("bad request")
+
+// request validate q and return the upstream request
+func (q *Query) request() (*filing.QueryRequest, error) {
+	if (q.Domain == "") == (q.Unit == "") {
+		return nil, fmt.Errorf("%w: exactly one of domain and unit is required", errBadRequest)
+	}
+	serviceType := filing.ServiceWebsite
+	if q.Type != "" {
+		t, err := filing.ParseServiceType(q.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unknown type %q", errBadRequest, q.Type)
+		}
+		serviceType = t
+	}
+	req := filing.NewQuery(q.Unit).Type(serviceType).Page(q.Page).Size(q.Size)
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", errBadRequest, err)
+	}
+	return req, nil
+}
+
+// lookup run q. A website domain without pagination is checked with
+// Filling.Check, any other domain query is sent for the registrable domain
+// and only matches its own records. The upstream page is returned with the
+// result.
+func (s *Server) lookup(ctx context.Context, q *Query) (*Result, *filing.QueryParams, error) {
+	req, err := q.request()
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	r := &Result{Query: q.Unit, Records: []*filing.DomainInfo{}}
+	if q.Domain != "" && req.ServiceType == filing.ServiceWebsite && q.Page == 0 && q.Size == 0 {
+		r.Query = q.Domain
+		v, err := s.filling.Check(ctx, q.Domain)
+		if errors.Is(err, filing.ErrInvalidDomain) {
+			return nil, nil, fmt.Errorf("%w: %w", errBadRequest, err)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		r.Domain, r.Filed, r.Verdict = v.Domain, v.Filed, v
+		if v.Record != nil {
+			r.Records = append(r.Records, v.Record)
+		}
+		r.Total = len(r.Records)
+		return r, paramsOf(v.Response), nil
+	}
+
+	if q.Domain != "" {
+		r.Query = q.Domain
+		resp, err := s.filling.DomainTLD(ctx, tld.Hostname(q.Domain), 0)
+		if err != nil || resp.Domain == "" {
+			return nil, nil, fmt.Errorf("%w: %w: %q", errBadRequest, filing.ErrInvalidDomain, q.Domain)
+		}
+		r.Domain = resp.Domain
+		req.UnitName = resp.Domain
+	}
+	resp, err := s.filling.DomainFilling(ctx, req)
+	if err == nil && resp == nil {
+		err = errors.New("response is nil")
+	}
+	if err == nil && !resp.Success {
+		err = errors.New("code: " + strconv.Itoa(resp.Code) + " errMsg: " + resp.Msg)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	params := paramsOf(resp)
+	r.Total = params.Total
+	for _, info := range params.List {
+		if r.Domain == "" || strings.EqualFold(strings.TrimSpace(info.Domain), r.Domain) {
+			r.Records = append(r.Records, info)
+		}
+	}
+	if r.Domain != "" {
+		r.Total = len(r.Records)
+	}
+	r.Filed = len(r.Records) > 0
+	return r, params, nil
+}
+
+// paramsOf return the page of resp, empty when there is none
+func paramsOf(resp *filing.QueryResponse) *filing.QueryParams {
+	if resp == nil || resp.Params == nil {
+		return &filing.QueryParams{}
+	}
+	return resp.Params
+}
+
+func (s *Server) handleFilings(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	q := &Query{Domain: values.Get("domain"), Unit: values.Get("unit"), Type: values.Get("type")}
+	for name, dst := range map[string]*int{"page": &q.Page, "size": &q.Size} {
+		if v := values.Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalid "+name)
+				return
+			}
+			*dst = n
+		}
+	}
+	result, _, err := s.lookup(r.Context(), q)
+	if err != nil {
+		s.fail(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// batchRequest is the body of a batch request
+type batchRequest struct {
+	Items []*Query `json:"items"`
+}
+
+func (s *Server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	var in batchRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+		return
+	}
+	switch {
+	case len(in.Items) == 0:
+		writeError(w, http.StatusBadRequest, "items is empty")
+		return
+	case len(in.Items) > s.maxBatch:
+		writeError(w, http.StatusBadRequest, "too many items, the limit is "+strconv.Itoa(s.maxBatch))
+		return
+	}
+	for i, q := range in.Items {
+		if q == nil {
+			writeError(w, http.StatusBadRequest, "item "+strconv.Itoa(i)+" is null")
+			return
+		}
+		if _, err := q.request(); err != nil {
+			writeError(w, http.StatusBadRequest, "item "+strconv.Itoa(i)+": "+err.Error())
+			return
+		}
+	}
+
+	results := make([]*Result, len(in.Items))
+	s.each(r.Context(), in.Items, func(i int, result *Result, _ *filing.QueryParams, err error) {
+		if err != nil {
+			s.logger.WarnContext(r.Context(), "batch item failed", "index", i, "error", err)
+			result = errorResult(in.Items[i], err)
+		}
+		results[i] = result
+	})
+	for i, result := range results {
+		if result == nil {
+			results[i] = errorResult(in.Items[i], r.Context().Err())
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string][]*Result{"results": results})
+}
+
+// each look up queries with the worker pool and call fn with every outcome,
+// fn is called concurrently. The queries not started when ctx is done are skipped.
+func (s *Server) each(ctx context.Context, queries []*Query, fn func(i int, r *Result, params *filing.QueryParams, err error)) {
+	var (
+		next = make(chan int)
+		wg   sync.WaitGroup
+	)
+	for n := 0; n < s.workers && n < len(queries); n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				r, params, err := s.lookup(ctx, queries[i])
+				fn(i, r, params, err)
+			}
+		}()
+	}
+loop:
+	for i := range queries {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+			break loop
+		}
+	}
+	close(next)
+	wg.Wait()
+}
+
+// errorResult return the result of a failed query
+func errorResult(q *Query, err error) *Result {
+	return &Result{Query: q.Domain + q.Unit, Records: []*filing.DomainInfo{}, Error: err.Error()}
+}
+
+func (s *Server) handleTLD(w http.ResponseWriter, r *http.Request) {
+	var (
+		values = r.URL.Query()
+		link   = values.Get("url")
+		level  int
+	)
+	if link == "" {
+		writeError(w, http.StatusBadRequest, "url is required")
+		return
+	}
+	if v := values.Get("level"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid level")
+			return
+		}
+		level = n
+	}
+	resp, err := tld.GetTLD(r.Context(), tld.Hostname(link), level)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	resp.Link = link
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// fail answer err with its status code
+func (s *Server) fail(w http.ResponseWriter, r *http.Request, err error) {
+	status := statusOf(err)
+	if status >= http.StatusInternalServerError {
+		s.logger.ErrorContext(r.Context(), "lookup failed", "path", r.URL.Path, "status", status, "error", err)
+	}
+	writeError(w, status, err.Error())
+}
+
+// statusOf return the HTTP status code of a lookup error
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, errBadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, filing.ErrChallengeRequired):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// writeError write a JSON error body
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+// writeJSON write v as the JSON body
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(v)
+}
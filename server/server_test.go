@@ -0,0 +1,178 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	filing "github.com/houseme/icp-filing"
+	"github.com/houseme/icp-filing/utility/request"
+)
+
+// newTestFilling return a client whose upstream answers baidu.com as filed,
+// fails on broken.com and challenges waf.com, with the count of queries
+func newTestFilling(t *testing.T) (*filing.Filling, *atomic.Int32) {
+	t.Helper()
+	var queries atomic.Int32
+	doer := request.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		status, body := http.StatusOK, `{"code":200,"success":true,"params":{"bussiness":"token","expire":300000}}`
+		if strings.HasSuffix(req.URL.Path, "queryByCondition") {
+			queries.Add(1)
+			var in filing.QueryRequest
+			_ = json.NewDecoder(req.Body).Decode(&in)
+			switch {
+			case in.UnitName == "baidu.com" && in.ServiceType == filing.ServiceApp:
+				body = fmt.Sprintf(`{"code":200,"success":true,"params":{"list":[{"domain":"baidu.com","serviceName":"百度APP"},{"domain":"baidu.cn","serviceName":"百度"}],"total":2,"pageNum":%d,"pageSize":%d}}`, in.PageNum, in.PageSize)
+			case in.UnitName == "baidu.com" || in.UnitName == "北京百度网讯科技有限公司":
+				body = `{"code":200,"success":true,"params":{"list":[{"domain":"baidu.com","unitName":"北京百度网讯科技有限公司"},{"domain":"baidu.cn","unitName":"北京百度网讯科技有限公司"}],"total":2}}`
+			case in.UnitName == "broken.com":
+				body = `{"code":500,"msg":"upstream failure","success":false}`
+			case in.UnitName == "waf.com":
+				status, body = http.StatusForbidden, `<html><body>waf</body></html>`
+			default:
+				body = `{"code":200,"success":true,"params":{"list":[],"total":0}}`
+			}
+		}
+		return &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	f, err := filing.NewWithError(context.Background(), filing.WithDoer(doer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, &queries
+}
+
+// newTestServer return an HTTP server of newTestFilling
+func newTestServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	f, queries := newTestFilling(t)
+	ts := httptest.NewServer(New(f, WithMaxBatch(3)))
+	t.Cleanup(ts.Close)
+	return ts, queries
+}
+
+func TestServer(t *testing.T) {
+	ts, _ := newTestServer(t)
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "domain", method: http.MethodGet, path: "/v1/filings?domain=https://www.baidu.com/index.html",
+			wantStatus: http.StatusOK, wantBody: `"domain":"baidu.com","filed":true,"total":1`},
+		{name: "domain with type and page", method: http.MethodGet, path: "/v1/filings?domain=www.baidu.com&type=app&page=3&size=5",
+			wantStatus: http.StatusOK, wantBody: `"serviceName":"百度APP"`},
+		{name: "not filed", method: http.MethodGet, path: "/v1/filings?domain=example.com",
+			wantStatus: http.StatusOK, wantBody: `"filed":false,"total":0,"records":[]`},
+		{name: "unit", method: http.MethodGet, path: "/v1/filings?unit=北京百度网讯科技有限公司&type=app",
+			wantStatus: http.StatusOK, wantBody: `"filed":true,"total":2`},
+		{name: "missing query", method: http.MethodGet, path: "/v1/filings",
+			wantStatus: http.StatusBadRequest, wantBody: `"error"`},
+		{name: "unknown type", method: http.MethodGet, path: "/v1/filings?unit=x&type=tv",
+			wantStatus: http.StatusBadRequest, wantBody: `unknown type`},
+		{name: "invalid page", method: http.MethodGet, path: "/v1/filings?unit=x&page=a",
+			wantStatus: http.StatusBadRequest, wantBody: `invalid page`},
+		{name: "upstream error", method: http.MethodGet, path: "/v1/filings?domain=broken.com",
+			wantStatus: http.StatusBadGateway, wantBody: `upstream failure`},
+		{name: "challenge", method: http.MethodGet, path: "/v1/filings?domain=waf.com",
+			wantStatus: http.StatusServiceUnavailable, wantBody: `challenge required`},
+		{name: "method", method: http.MethodPost, path: "/v1/filings?domain=baidu.com",
+			wantStatus: http.StatusMethodNotAllowed},
+		{name: "batch", method: http.MethodPost, path: "/v1/filings:batch",
+			body:       `{"items":[{"domain":"baidu.com"},{"domain":"broken.com"},{"unit":"北京百度网讯科技有限公司","type":"website"}]}`,
+			wantStatus: http.StatusOK, wantBody: `"error":"code: 500 errMsg: upstream failure"`},
+		{name: "batch too large", method: http.MethodPost, path: "/v1/filings:batch",
+			body:       `{"items":[{"domain":"a.com"},{"domain":"b.com"},{"domain":"c.com"},{"domain":"d.com"}]}`,
+			wantStatus: http.StatusBadRequest, wantBody: `too many items`},
+		{name: "batch invalid item", method: http.MethodPost, path: "/v1/filings:batch",
+			body:       `{"items":[{"domain":"a.com","unit":"x"}]}`,
+			wantStatus: http.StatusBadRequest, wantBody: `item 0`},
+		{name: "tld", method: http.MethodGet, path: "/v1/tld?url=https://mp.weixin.qq.com/s",
+			wantStatus: http.StatusOK, wantBody: `"domain":"qq.com"`},
+		{name: "tld missing url", method: http.MethodGet, path: "/v1/tld",
+			wantStatus: http.StatusBadRequest},
+		{name: "healthz", method: http.MethodGet, path: "/healthz",
+			wantStatus: http.StatusOK, wantBody: `"ok"`},
+		{name: "not found", method: http.MethodGet, path: "/v2",
+			wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, ts.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := ts.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d, body %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if !strings.Contains(string(body), tt.wantBody) {
+				t.Errorf("body = %s, want it to contain %s", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestServer_BatchOrder(t *testing.T) {
+	ts, queries := newTestServer(t)
+	resp, err := ts.Client().Post(ts.URL+"/v1/filings:batch", "application/json",
+		strings.NewReader(`{"items":[{"domain":"example.com"},{"domain":"baidu.com"},{"domain":"broken.com"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var out struct {
+		Results []*Result `json:"results"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Results) != 3 {
+		t.Fatalf("results = %d, want 3", len(out.Results))
+	}
+	for i, want := range []struct {
+		query string
+		filed bool
+		err   bool
+	}{{"example.com", false, false}, {"baidu.com", true, false}, {"broken.com", false, true}} {
+		got := out.Results[i]
+		if got.Query != want.query || got.Filed != want.filed || (got.Error != "") != want.err {
+			t.Errorf("results[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+	if got := queries.Load(); got != 3 {
+		t.Errorf("upstream queries = %d, want 3", got)
+	}
+}
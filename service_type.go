@@ -0,0 +1,152 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ServiceType is the kind of the filed service, the values are the upstream ones
+type ServiceType int
+
+// Service types
+const (
+	ServiceWebsite     ServiceType = 1 // 网站
+	ServiceApp         ServiceType = 6 // APP
+	ServiceMiniProgram ServiceType = 7 // 小程序
+	ServiceQuickApp    ServiceType = 8 // 快应用
+)
+
+// ErrInvalidServiceType is returned for a service type the upstream does not know
+var ErrInvalidServiceType = errors.New("invalid service type")
+
+// serviceTypeNames are the names of String and MarshalText
+var serviceTypeNames = map[ServiceType]string{
+	ServiceWebsite:     "website",
+	ServiceApp:         "app",
+	ServiceMiniProgram: "miniprogram",
+	ServiceQuickApp:    "quickapp",
+}
+
+// serviceTypeAliases are the other names accepted by ParseServiceType
+var serviceTypeAliases = map[string]ServiceType{
+	"网站":           ServiceWebsite,
+	"mini_program": ServiceMiniProgram,
+	"mini-program": ServiceMiniProgram,
+	"小程序":          ServiceMiniProgram,
+	"quick_app":    ServiceQuickApp,
+	"quick-app":    ServiceQuickApp,
+	"快应用":          ServiceQuickApp,
+}
+
+// ParseServiceType parse a service type from its name, such as "app" or "小程序",
+// or from its upstream number, such as "6"
+func ParseServiceType(s string) (ServiceType, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if n, err := strconv.Atoi(s); err == nil {
+		if t := ServiceType(n); t.Valid() {
+			return t, nil
+		}
+	}
+	for t, name := range serviceTypeNames {
+		if s == name {
+			return t, nil
+		}
+	}
+	if t, ok := serviceTypeAliases[s]; ok {
+		return t, nil
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidServiceType, s)
+}
+
+// Valid reports whether t is known by the upstream
+func (t ServiceType) Valid() bool {
+	_, ok := serviceTypeNames[t]
+	return ok
+}
+
+// String return the name of t
+func (t ServiceType) String() string {
+	if name, ok := serviceTypeNames[t]; ok {
+		return name
+	}
+	return "ServiceType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (t ServiceType) MarshalText() ([]byte, error) {
+	if !t.Valid() {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidServiceType, int(t))
+	}
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, see ParseServiceType
+func (t *ServiceType) UnmarshalText(text []byte) error {
+	v, err := ParseServiceType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, t is a number as the upstream expects
+func (t ServiceType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(t))), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, it accepts a number or a name
+func (t *ServiceType) UnmarshalJSON(data []byte) error {
+	var n int
+	if err := json.Unmarshal(data, &n); err == nil {
+		*t = ServiceType(n)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidServiceType, data)
+	}
+	return t.UnmarshalText([]byte(s))
+}
+
+// QueryApp query the APP filings of name, a company or an APP name
+func (i *Filling) QueryApp(ctx context.Context, name string) (*QueryResponse, error) {
+	return i.queryService(ctx, ServiceApp, name)
+}
+
+// QueryMiniProgram query the mini program filings of name, a company or a mini program name
+func (i *Filling) QueryMiniProgram(ctx context.Context, name string) (*QueryResponse, error) {
+	return i.queryService(ctx, ServiceMiniProgram, name)
+}
+
+// QueryQuickApp query the quick app filings of name, a company or a quick app name
+func (i *Filling) QueryQuickApp(ctx context.Context, name string) (*QueryResponse, error) {
+	return i.queryService(ctx, ServiceQuickApp, name)
+}
+
+// queryService query the first page of the filings of name with service type t
+func (i *Filling) queryService(ctx context.Context, t ServiceType, name string) (*QueryResponse, error) {
+	return i.DomainFilling(ctx, NewQuery(name).Type(t))
+}
@@ -0,0 +1,118 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestParseServiceType(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ServiceType
+		wantErr bool
+	}{
+		{in: "website", want: ServiceWebsite},
+		{in: "APP", want: ServiceApp},
+		{in: " miniprogram ", want: ServiceMiniProgram},
+		{in: "mini_program", want: ServiceMiniProgram},
+		{in: "小程序", want: ServiceMiniProgram},
+		{in: "快应用", want: ServiceQuickApp},
+		{in: "6", want: ServiceApp},
+		{in: "8", want: ServiceQuickApp},
+		{in: "3", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "tv", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseServiceType(tt.in)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidServiceType)) {
+				t.Fatalf("ParseServiceType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseServiceType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceType_Encoding(t *testing.T) {
+	for _, st := range []ServiceType{ServiceWebsite, ServiceApp, ServiceMiniProgram, ServiceQuickApp} {
+		text, err := st.MarshalText()
+		if err != nil {
+			t.Fatalf("%v.MarshalText() error = %v", st, err)
+		}
+		var got ServiceType
+		if err = got.UnmarshalText(text); err != nil || got != st {
+			t.Errorf("UnmarshalText(%s) = %v, %v, want %v", text, got, err, st)
+		}
+	}
+	if _, err := ServiceType(2).MarshalText(); !errors.Is(err, ErrInvalidServiceType) {
+		t.Errorf("ServiceType(2).MarshalText() error = %v, want ErrInvalidServiceType", err)
+	}
+	if got := ServiceType(2).String(); got != "ServiceType(2)" {
+		t.Errorf("ServiceType(2).String() = %q", got)
+	}
+
+	// the upstream expects a number
+	b, err := json.Marshal(&QueryRequest{UnitName: "x", ServiceType: ServiceApp})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wire map[string]any
+	if err = json.Unmarshal(b, &wire); err != nil || wire["serviceType"] != float64(6) {
+		t.Errorf("json.Marshal() = %s, want serviceType 6", b)
+	}
+	var req QueryRequest
+	if err = json.Unmarshal([]byte(`{"serviceType":"miniprogram"}`), &req); err != nil || req.ServiceType != ServiceMiniProgram {
+		t.Errorf("json.Unmarshal() = %v, %v, want miniprogram", req.ServiceType, err)
+	}
+}
+
+func TestFilling_QueryApp(t *testing.T) {
+	ctx := context.Background()
+	var got []ServiceType
+	f := New(ctx, WithDoer(queryDoer(func(req *http.Request) (int, string, string) {
+		var in QueryRequest
+		_ = json.NewDecoder(req.Body).Decode(&in)
+		got = append(got, in.ServiceType)
+		return http.StatusOK, "application/json", testQueryBody
+	})))
+	if _, err := f.QueryApp(ctx, "北京百度网讯科技有限公司"); err != nil {
+		t.Fatalf("QueryApp() error = %v", err)
+	}
+	if _, err := f.QueryMiniProgram(ctx, "北京百度网讯科技有限公司"); err != nil {
+		t.Fatalf("QueryMiniProgram() error = %v", err)
+	}
+	if _, err := f.DomainFilling(ctx, &QueryRequest{UnitName: "baidu.com"}); err != nil {
+		t.Fatalf("DomainFilling() error = %v", err)
+	}
+	want := []ServiceType{ServiceApp, ServiceMiniProgram, ServiceWebsite}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("service types = %v, want %v", got, want)
+	}
+	if _, err := f.QueryFilling(ctx, &QueryRequest{UnitName: "baidu.com", ServiceType: 2}); !errors.Is(err, ErrInvalidServiceType) {
+		t.Errorf("QueryFilling() error = %v, want ErrInvalidServiceType", err)
+	}
+}
@@ -0,0 +1,238 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Snapshot is the records of a query at a point in time, for change tracking
+type Snapshot struct {
+	// Query is the unit name or domain queried
+	Query string `json:"query"`
+	// ServiceType is the service type queried
+	ServiceType ServiceType `json:"serviceType"`
+	// TakenAt is the time of the query
+	TakenAt time.Time `json:"takenAt"`
+	// Records is every record of the query
+	Records []*DomainInfo `json:"records"`
+}
+
+// String return the snapshot as JSON
+func (s *Snapshot) String() string {
+	return toJSON(s)
+}
+
+// Snapshot return every record of query, read across every page. A zero
+// service type queries websites.
+func (i *Filling) Snapshot(ctx context.Context, query string, t ServiceType) (*Snapshot, error) {
+	if t == 0 {
+		t = ServiceWebsite
+	}
+	query = strings.TrimSpace(query)
+	records, err := i.queryAll(ctx, NewQuery(query).Type(t))
+	if err != nil {
+		return nil, err
+	}
+	if records == nil {
+		records = []*DomainInfo{}
+	}
+	return &Snapshot{Query: query, ServiceType: t, TakenAt: now(), Records: records}, nil
+}
+
+// ChangeKind is the kind of change of a record
+type ChangeKind string
+
+// Kinds of change
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// FieldChange is a field whose value changed, named as in JSON
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// RecordChange is a record added, removed or modified between two snapshots
+type RecordChange struct {
+	// Key identifies the record, its domain or service name
+	Key string `json:"key"`
+	// Kind is the kind of change
+	Kind ChangeKind `json:"kind"`
+	// Old is the record in the old snapshot, nil when added
+	Old *DomainInfo `json:"old,omitempty"`
+	// New is the record in the new snapshot, nil when removed
+	New *DomainInfo `json:"new,omitempty"`
+	// Fields is the changed fields of a modified record
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// Changed reports whether one of fields changed, such as "unitName"
+func (c *RecordChange) Changed(fields ...string) bool {
+	for _, fc := range c.Fields {
+		for _, f := range fields {
+			if fc.Field == f {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// LicenceChanged reports whether the main or service licence changed
+func (c *RecordChange) LicenceChanged() bool {
+	return c.Changed("mainLicence", "serviceLicence")
+}
+
+// UnitChanged reports whether the unit name changed
+func (c *RecordChange) UnitChanged() bool {
+	return c.Changed("unitName")
+}
+
+// Cancelled reports whether the record is no longer filed
+func (c *RecordChange) Cancelled() bool {
+	return c.Kind == ChangeRemoved
+}
+
+// Changes is the difference between two snapshots, each list sorted by key
+type Changes struct {
+	Added    []*RecordChange `json:"added"`
+	Removed  []*RecordChange `json:"removed"`
+	Modified []*RecordChange `json:"modified"`
+}
+
+// Empty reports whether nothing changed
+func (c *Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Modified) == 0
+}
+
+// All return the added, removed and modified records
+func (c *Changes) All() []*RecordChange {
+	all := make([]*RecordChange, 0, len(c.Added)+len(c.Removed)+len(c.Modified))
+	all = append(all, c.Added...)
+	all = append(all, c.Removed...)
+	return append(all, c.Modified...)
+}
+
+// String return the changes as JSON
+func (c *Changes) String() string {
+	return toJSON(c)
+}
+
+// Diff return the records added, removed and modified from one snapshot to
+// the next. A record is identified by its domain, or its service name for
+// apps, case insensitively. A nil snapshot has no records.
+func Diff(from, to *Snapshot) *Changes {
+	before, after := recordsByKey(from), recordsByKey(to)
+	c := &Changes{Added: []*RecordChange{}, Removed: []*RecordChange{}, Modified: []*RecordChange{}}
+	for _, key := range sortedKeys(after) {
+		prev, ok := before[key]
+		if !ok {
+			c.Added = append(c.Added, &RecordChange{Key: key, Kind: ChangeAdded, New: after[key]})
+			continue
+		}
+		if fields := diffFields(prev, after[key]); len(fields) > 0 {
+			c.Modified = append(c.Modified, &RecordChange{Key: key, Kind: ChangeModified, Old: prev, New: after[key], Fields: fields})
+		}
+	}
+	for _, key := range sortedKeys(before) {
+		if _, ok := after[key]; !ok {
+			c.Removed = append(c.Removed, &RecordChange{Key: key, Kind: ChangeRemoved, Old: before[key]})
+		}
+	}
+	return c
+}
+
+// recordKey return the identity of a record
+func recordKey(info *DomainInfo) string {
+	if k := strings.ToLower(strings.TrimSpace(info.Domain)); k != "" {
+		return k
+	}
+	if k := strings.ToLower(strings.TrimSpace(info.ServiceName)); k != "" {
+		return k
+	}
+	return "service:" + strconv.FormatInt(info.ServiceID, 10)
+}
+
+// recordsByKey index the records of s, the first one wins on duplicates
+func recordsByKey(s *Snapshot) map[string]*DomainInfo {
+	m := make(map[string]*DomainInfo)
+	if s == nil {
+		return m
+	}
+	for _, info := range s.Records {
+		if info == nil {
+			continue
+		}
+		if k := recordKey(info); m[k] == nil {
+			m[k] = info
+		}
+	}
+	return m
+}
+
+// sortedKeys return the keys of m, sorted
+func sortedKeys(m map[string]*DomainInfo) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// recordFields is the compared fields of a record, in JSON order
+var recordFields = []struct {
+	name  string
+	value func(*DomainInfo) string
+}{
+	{"contentTypeName", func(r *DomainInfo) string { return r.ContentTypeName }},
+	{"domain", func(r *DomainInfo) string { return r.Domain }},
+	{"domainId", func(r *DomainInfo) string { return strconv.FormatInt(r.DomainID, 10) }},
+	{"homeUrl", func(r *DomainInfo) string { return r.HomeURL }},
+	{"leaderName", func(r *DomainInfo) string { return r.LeaderName }},
+	{"limitAccess", func(r *DomainInfo) string { return r.LimitAccess }},
+	{"mainId", func(r *DomainInfo) string { return strconv.FormatInt(r.MainID, 10) }},
+	{"mainLicence", func(r *DomainInfo) string { return r.MainLicence }},
+	{"natureName", func(r *DomainInfo) string { return r.NatureName }},
+	{"serviceId", func(r *DomainInfo) string { return strconv.FormatInt(r.ServiceID, 10) }},
+	{"serviceLicence", func(r *DomainInfo) string { return r.ServiceLicence }},
+	{"serviceName", func(r *DomainInfo) string { return r.ServiceName }},
+	{"unitName", func(r *DomainInfo) string { return r.UnitName }},
+	{"updateRecordTime", func(r *DomainInfo) string { return r.UpdateRecordTime }},
+}
+
+// diffFields return the fields with different values in a and b
+func diffFields(a, b *DomainInfo) []FieldChange {
+	var fields []FieldChange
+	for _, f := range recordFields {
+		if o, n := f.value(a), f.value(b); o != n {
+			fields = append(fields, FieldChange{Field: f.name, Old: o, New: n})
+		}
+	}
+	return fields
+}
@@ -0,0 +1,118 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	old := &Snapshot{Query: "北京百度网讯科技有限公司", Records: []*DomainInfo{
+		{Domain: "baidu.com", UnitName: "北京百度网讯科技有限公司", ServiceLicence: "京ICP证030173号-1", ServiceID: 1},
+		{Domain: "baidu.cn", UnitName: "北京百度网讯科技有限公司", ServiceLicence: "京ICP证030173号-2"},
+		{Domain: "hao123.com", UnitName: "北京百度网讯科技有限公司"},
+		nil,
+	}}
+	next := &Snapshot{Query: "北京百度网讯科技有限公司", Records: []*DomainInfo{
+		{Domain: "hao123.com", UnitName: "北京百度网讯科技有限公司"},
+		{Domain: "BAIDU.com", UnitName: "北京百度网讯科技有限公司", ServiceLicence: "京ICP证030173号-3", ServiceID: 2},
+		{Domain: "baidu.cn", UnitName: "北京百度网讯有限公司", ServiceLicence: "京ICP证030173号-2"},
+		{Domain: "baidu.net", UnitName: "北京百度网讯科技有限公司"},
+	}}
+
+	c := Diff(old, next)
+	if keys := changeKeys(c.Added); !reflect.DeepEqual(keys, []string{"baidu.net"}) {
+		t.Errorf("Added = %v", keys)
+	}
+	if keys := changeKeys(c.Removed); len(keys) != 0 {
+		t.Errorf("Removed = %v, want none", keys)
+	}
+	if keys := changeKeys(c.Modified); !reflect.DeepEqual(keys, []string{"baidu.cn", "baidu.com"}) {
+		t.Fatalf("Modified = %v", keys)
+	}
+	if cn := c.Modified[0]; !cn.UnitChanged() || cn.LicenceChanged() || len(cn.Fields) != 1 {
+		t.Errorf("Modified[baidu.cn] = %s", toJSON(cn))
+	}
+	want := []FieldChange{
+		{Field: "domain", Old: "baidu.com", New: "BAIDU.com"},
+		{Field: "serviceId", Old: "1", New: "2"},
+		{Field: "serviceLicence", Old: "京ICP证030173号-1", New: "京ICP证030173号-3"},
+	}
+	if com := c.Modified[1]; !reflect.DeepEqual(com.Fields, want) || !com.LicenceChanged() || com.UnitChanged() {
+		t.Errorf("Modified[baidu.com].Fields = %v, want %v", com.Fields, want)
+	}
+	if len(c.All()) != 3 || c.Empty() {
+		t.Errorf("All() = %d changes", len(c.All()))
+	}
+
+	back := Diff(next, old)
+	if len(back.Removed) != 1 || !back.Removed[0].Cancelled() || back.Removed[0].Old.Domain != "baidu.net" {
+		t.Errorf("Removed = %s", toJSON(back.Removed))
+	}
+	if c := Diff(old, old); !c.Empty() {
+		t.Errorf("Diff(old, old) = %s, want empty", c)
+	}
+	if c := Diff(nil, &Snapshot{Records: []*DomainInfo{{ServiceName: "百度", ServiceID: 7}}}); len(c.Added) != 1 || c.Added[0].Key != "百度" {
+		t.Errorf("Diff(nil, app) = %s", c)
+	}
+}
+
+func changeKeys(changes []*RecordChange) []string {
+	keys := []string{}
+	for _, c := range changes {
+		keys = append(keys, c.Key)
+	}
+	return keys
+}
+
+func TestFilling_Snapshot(t *testing.T) {
+	defer func(f func() time.Time) { now = f }(now)
+	at := time.Date(2024, 5, 1, 8, 0, 0, 0, Beijing)
+	now = func() time.Time { return at }
+
+	ctx := context.Background()
+	f := New(ctx, WithDoer(recordsDoer(func(QueryRequest) []*DomainInfo {
+		records := make([]*DomainInfo, MaxPageSize+1)
+		for i := range records {
+			records[i] = &DomainInfo{Domain: "site" + strconv.Itoa(i) + ".com", ServiceID: int64(i)}
+		}
+		return records
+	})))
+
+	s, err := f.Snapshot(ctx, " 北京百度网讯科技有限公司 ", 0)
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	if s.Query != "北京百度网讯科技有限公司" || s.ServiceType != ServiceWebsite || !s.TakenAt.Equal(at) || len(s.Records) != MaxPageSize+1 {
+		t.Errorf("Snapshot() = %s with %d records", s.Query, len(s.Records))
+	}
+
+	var back Snapshot
+	if err := json.Unmarshal([]byte(s.String()), &back); err != nil {
+		t.Fatalf("Unmarshal(String()) error = %v", err)
+	}
+	if c := Diff(s, &back); !c.Empty() || !back.TakenAt.Equal(at) {
+		t.Errorf("Diff(s, decoded s) = %s", c)
+	}
+}
@@ -22,9 +22,18 @@ import (
 	"context"
 
 	"github.com/houseme/icp-filing/tld"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // DomainTLD is a struct that contains the TLD and the domain name
 func (i *Filling) DomainTLD(ctx context.Context, link string, level int) (resp *tld.DomainTLDResp, err error) {
+	ctx, span := i.tracer.Start(ctx, "tld.GetTLD", trace.WithAttributes(attrLink.String(link)))
+	defer func() {
+		if resp != nil {
+			span.SetAttributes(attrDomain.String(resp.Domain))
+		}
+		endSpan(span, err)
+	}()
+
 	return tld.GetTLD(ctx, link, level)
 }
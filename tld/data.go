@@ -19,6 +19,7 @@
 package tld
 
 import (
+	"encoding/json"
 	"strconv"
 )
 
@@ -38,7 +39,11 @@ type DomainTLDResp struct {
 
 // String return tld response string
 func (r *DomainTLDResp) String() string {
-	return `{"subdomain": "` + r.SubDomain + `", "domain": "` + r.Domain + `", "tld": "` + r.Tld + `", "label": ` + strconv.Itoa(r.Label) + `, "link": "` + r.Link + `"}`
+	b, err := json.Marshal(r)
+	if err != nil {
+		return `{"error":` + strconv.Quote(err.Error()) + `}`
+	}
+	return string(b)
 }
 
 func initTld() {
@@ -20,6 +20,7 @@ package tld
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 )
 
@@ -42,6 +43,33 @@ func TestGetTld(t *testing.T) {
 	// t.Fail()
 }
 
+func TestHostname(t *testing.T) {
+	tests := map[string]string{
+		"https://WWW.Baidu.com:443/path": "www.baidu.com",
+		"www.baidu.com:8080/index.html":  "www.baidu.com",
+		"baidu.com.":                     "baidu.com",
+		"https://www.baidu.com./x":       "www.baidu.com",
+		"www.baidu.com.:443/x":           "www.baidu.com",
+		"北京百度网讯科技有限公司":                   "北京百度网讯科技有限公司",
+	}
+	for in, want := range tests {
+		if got := Hostname(in); got != want {
+			t.Errorf("Hostname(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func FuzzDomainTLDResp_String(f *testing.F) {
+	f.Add("https://www.baidu.com/", "www.baidu.com", 1)
+	f.Add(`"}{\`, "\x00\n", -1)
+	f.Fuzz(func(t *testing.T, link, domain string, label int) {
+		s := (&DomainTLDResp{Link: link, Domain: domain, SubDomain: domain, Label: label}).String()
+		if !json.Valid([]byte(s)) {
+			t.Fatalf("String() = %q, not valid JSON", s)
+		}
+	})
+}
+
 func BenchmarkGetTld(b *testing.B) {
 	b.ReportAllocs()
 	b.ResetTimer()
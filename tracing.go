@@ -0,0 +1,86 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	// instrumentationName is the name of the tracer
+	instrumentationName = "github.com/houseme/icp-filing"
+
+	attrServiceType  = attribute.Key("icp.service_type")
+	attrPageNum      = attribute.Key("icp.page_num")
+	attrPageSize     = attribute.Key("icp.page_size")
+	attrPath         = attribute.Key("icp.path")
+	attrUpstreamCode = attribute.Key("icp.upstream.code")
+	attrRetryCount   = attribute.Key("icp.retry.count")
+	attrCacheHit     = attribute.Key("icp.cache.hit")
+	attrHTTPStatus   = attribute.Key("http.response.status_code")
+	attrLink         = attribute.Key("icp.link")
+	attrDomain       = attribute.Key("icp.domain")
+)
+
+// WithTracerProvider is the option for OpenTelemetry tracing, no spans are
+// recorded without it.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *options) {
+		o.TracerProvider = tp
+	}
+}
+
+// newTracer return the tracer of tp, or a no-op tracer
+func newTracer(tp trace.TracerProvider) trace.Tracer {
+	if tp == nil {
+		tp = noop.NewTracerProvider()
+	}
+	return tp.Tracer(instrumentationName)
+}
+
+// startSpan start a client span named name
+func (i *Filling) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return i.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// queryAttributes return the span attributes of req
+func queryAttributes(req *QueryRequest) []attribute.KeyValue {
+	if req == nil {
+		return nil
+	}
+	return []attribute.KeyValue{
+		attrServiceType.Int(int(req.ServiceType)),
+		attrPageNum.Int(req.PageNum),
+		attrPageSize.Int(req.PageSize),
+	}
+}
+
+// endSpan record err on span and end it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
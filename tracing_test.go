@@ -0,0 +1,146 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package filling
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// spanAttr return the value of key on span
+func spanAttr(span tracetest.SpanStub, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestFilling_WithTracerProvider(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		exporter = tracetest.NewInMemoryExporter()
+		tp       = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		f        = New(ctx, WithTracerProvider(tp), WithMiddleware(stubMiddleware))
+	)
+	defer func() {
+		_ = tp.Shutdown(ctx)
+	}()
+
+	ctx, parent := tp.Tracer("test").Start(ctx, "caller")
+	_, err := f.DomainFilling(ctx, &QueryRequest{Link: "www.baidu.com", ServiceType: 1, PageNum: 2})
+	parent.End()
+	if err != nil {
+		t.Fatalf("DomainFilling() error = %v", err)
+	}
+
+	spans := make(map[string]tracetest.SpanStub)
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+	domain, ok := spans["DomainFilling"]
+	if !ok {
+		t.Fatalf("no DomainFilling span in %v", exporter.GetSpans().Snapshots())
+	}
+	if domain.Parent.SpanID() != spans["caller"].SpanContext.SpanID() {
+		t.Errorf("DomainFilling parent = %v, want the caller span", domain.Parent.SpanID())
+	}
+	for _, name := range []string{"tld.GetTLD", "authorize", "QueryFilling"} {
+		span, ok := spans[name]
+		if !ok {
+			t.Errorf("no %s span", name)
+			continue
+		}
+		if span.Parent.SpanID() != domain.SpanContext.SpanID() {
+			t.Errorf("%s parent = %v, want DomainFilling", name, span.Parent.SpanID())
+		}
+	}
+
+	tests := []struct {
+		span string
+		key  attribute.Key
+		want attribute.Value
+	}{
+		{span: "DomainFilling", key: attrServiceType, want: attribute.IntValue(1)},
+		{span: "QueryFilling", key: attrPageNum, want: attribute.IntValue(2)},
+		{span: "QueryFilling", key: attrUpstreamCode, want: attribute.IntValue(200)},
+		{span: "QueryFilling", key: attrRetryCount, want: attribute.IntValue(0)},
+		{span: "QueryFilling", key: attrHTTPStatus, want: attribute.IntValue(http.StatusOK)},
+		{span: "authorize", key: attrUpstreamCode, want: attribute.IntValue(200)},
+		{span: "tld.GetTLD", key: attrDomain, want: attribute.StringValue("baidu.com")},
+	}
+	for _, tt := range tests {
+		if got, ok := spanAttr(spans[tt.span], tt.key); !ok || got != tt.want {
+			t.Errorf("%s %s = %v, want %v", tt.span, tt.key, got.Emit(), tt.want.Emit())
+		}
+	}
+}
+
+func TestFilling_TracingError(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		exporter = tracetest.NewInMemoryExporter()
+		tp       = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		f        = New(ctx, WithTracerProvider(tp), WithDoer(queryDoer(func(*http.Request) (int, string, string) {
+			return http.StatusOK, "text/html", testWAFPage
+		})))
+	)
+	if _, err := f.DomainFilling(ctx, &QueryRequest{UnitName: "baidu.com"}); err == nil {
+		t.Fatal("DomainFilling() error = nil, want a challenge error")
+	}
+	for _, span := range exporter.GetSpans() {
+		if span.Name == "QueryFilling" || span.Name == "DomainFilling" {
+			if span.Status.Code != codes.Error || len(span.Events) == 0 {
+				t.Errorf("%s status = %v, events = %d", span.Name, span.Status, len(span.Events))
+			}
+		}
+	}
+}
+
+func TestFilling_CheckTracing(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		exporter = tracetest.NewInMemoryExporter()
+		tp       = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		f        = New(ctx, WithTracerProvider(tp), WithMiddleware(stubMiddleware))
+	)
+	defer func() {
+		_ = tp.Shutdown(ctx)
+	}()
+
+	if _, err := f.Check(ctx, "https://www.baidu.com/index.html"); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	for _, span := range exporter.GetSpans() {
+		if span.Name == "tld.GetTLD" {
+			if got, _ := spanAttr(span, attrDomain); got != attribute.StringValue("baidu.com") {
+				t.Errorf("tld.GetTLD %s = %v, want baidu.com", attrDomain, got.Emit())
+			}
+			return
+		}
+	}
+	t.Errorf("no tld.GetTLD span in %v", exporter.GetSpans().Snapshots())
+}
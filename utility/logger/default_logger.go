@@ -24,6 +24,8 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"runtime"
+	"time"
 )
 
 // DefaultLogger 默认日志
@@ -41,44 +43,59 @@ func NewDefaultLogger() *DefaultLogger {
 	}
 }
 
+// Slog 返回底层的 slog.Logger
+func (logger *DefaultLogger) Slog() *slog.Logger {
+	return logger.logger
+}
+
+// log 输出一条日志，source 指向调用方
+func (logger *DefaultLogger) log(ctx context.Context, level slog.Level, msg string) {
+	if !logger.logger.Enabled(ctx, level) {
+		return
+	}
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // skip [Callers, log, exported method]
+	_ = logger.logger.Handler().Handle(ctx, slog.NewRecord(time.Now(), level, msg, pcs[0]))
+}
+
 // Debug 调试
 func (logger *DefaultLogger) Debug(ctx context.Context, v ...any) {
-	logger.logger.DebugContext(ctx, "debug level", v...)
+	logger.log(ctx, slog.LevelDebug, fmt.Sprint(v...))
 }
 
 // Debugf 调试
 func (logger *DefaultLogger) Debugf(ctx context.Context, format string, v ...any) {
-	logger.logger.DebugContext(ctx, "debug level", fmt.Sprintf(format, v...), "")
+	logger.log(ctx, slog.LevelDebug, fmt.Sprintf(format, v...))
 }
 
 // Info 信息
 func (logger *DefaultLogger) Info(ctx context.Context, v ...any) {
-	logger.logger.InfoContext(ctx, "info level", v...)
+	logger.log(ctx, slog.LevelInfo, fmt.Sprint(v...))
 }
 
 // Infof 信息
 func (logger *DefaultLogger) Infof(ctx context.Context, format string, v ...any) {
-	logger.logger.InfoContext(ctx, "info level", fmt.Sprintf(format, v...), nil)
+	logger.log(ctx, slog.LevelInfo, fmt.Sprintf(format, v...))
 }
 
 // Error 错误
 func (logger *DefaultLogger) Error(ctx context.Context, v ...any) {
-	logger.logger.ErrorContext(ctx, "error level", v...)
+	logger.log(ctx, slog.LevelError, fmt.Sprint(v...))
 }
 
 // Errorf 错误
 func (logger *DefaultLogger) Errorf(ctx context.Context, format string, v ...any) {
-	logger.logger.ErrorContext(ctx, "error level", fmt.Sprintf(format, v...), nil)
+	logger.log(ctx, slog.LevelError, fmt.Sprintf(format, v...))
 }
 
 // Fatal 致命错误
 func (logger *DefaultLogger) Fatal(ctx context.Context, v ...any) {
-	logger.logger.ErrorContext(ctx, "fatal level", v...)
+	logger.log(ctx, slog.LevelError, fmt.Sprint(v...))
 	os.Exit(1)
 }
 
 // Fatalf 致命错误
 func (logger *DefaultLogger) Fatalf(ctx context.Context, format string, v ...any) {
-	logger.logger.ErrorContext(ctx, "fatal level", fmt.Sprintf(format, v...), nil)
+	logger.log(ctx, slog.LevelError, fmt.Sprintf(format, v...))
 	os.Exit(1)
 }
@@ -0,0 +1,140 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// NewHandler adapts an ILogger to slog.Handler, each record is written as its
+// message followed by key=value attributes. Records below slog.LevelInfo go to
+// Debugf, below slog.LevelError to Infof and the rest to Errorf.
+// A *DefaultLogger is unwrapped to its own handler.
+func NewHandler(l ILogger) slog.Handler {
+	if dl, ok := l.(*DefaultLogger); ok {
+		return dl.Slog().Handler()
+	}
+	return &handler{logger: l}
+}
+
+// handler is the slog.Handler over ILogger
+type handler struct {
+	logger ILogger
+	attrs  []slog.Attr
+	group  string
+}
+
+// Enabled implements slog.Handler, the ILogger does its own filtering
+func (h *handler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle implements slog.Handler
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	var b strings.Builder
+	b.WriteString(r.Message)
+	for _, a := range h.attrs {
+		writeAttr(&b, "", a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		writeAttr(&b, h.group, a)
+		return true
+	})
+
+	switch {
+	case r.Level < slog.LevelInfo:
+		h.logger.Debugf(ctx, "%s", b.String())
+	case r.Level < slog.LevelError:
+		h.logger.Infof(ctx, "%s", b.String())
+	default:
+		h.logger.Errorf(ctx, "%s", b.String())
+	}
+	return nil
+}
+
+// WithAttrs implements slog.Handler
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := *h
+	out.attrs = make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	out.attrs = append(out.attrs, h.attrs...)
+	for _, a := range attrs {
+		if h.group != "" {
+			a.Key = h.group + "." + a.Key
+		}
+		out.attrs = append(out.attrs, a)
+	}
+	return &out
+}
+
+// WithGroup implements slog.Handler
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	out := *h
+	if out.group != "" {
+		out.group += "."
+	}
+	out.group += name
+	return &out
+}
+
+// writeAttr write a as " key=value", groups are flattened with dots
+func writeAttr(b *strings.Builder, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	key := a.Key
+	if prefix != "" && key != "" {
+		key = prefix + "." + key
+	} else if key == "" {
+		key = prefix
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		for _, ga := range a.Value.Group() {
+			writeAttr(b, key, ga)
+		}
+		return
+	}
+	b.WriteByte(' ')
+	b.WriteString(key)
+	b.WriteByte('=')
+	b.WriteString(a.Value.String())
+}
+
+// DiscardHandler discards every record, it is the default of the filling client
+var DiscardHandler slog.Handler = discardHandler{}
+
+// discardHandler is a slog.Handler that does nothing
+type discardHandler struct{}
+
+// Enabled implements slog.Handler
+func (discardHandler) Enabled(context.Context, slog.Level) bool { return false }
+
+// Handle implements slog.Handler
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+
+// WithAttrs implements slog.Handler
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler { return d }
+
+// WithGroup implements slog.Handler
+func (d discardHandler) WithGroup(string) slog.Handler { return d }
@@ -43,11 +43,28 @@ const (
 
 // DefaultRequest 默认请求
 type DefaultRequest struct {
+	maxResponseSize int64
+}
+
+// Option is the option for DefaultRequest
+type Option func(srv *DefaultRequest)
+
+// WithMaxResponseSize 设置响应体大小上限，超出时返回 ErrResponseTooLarge
+func WithMaxResponseSize(size int64) Option {
+	return func(srv *DefaultRequest) {
+		srv.maxResponseSize = size
+	}
 }
 
 // NewDefaultRequest 实例化
-func NewDefaultRequest() *DefaultRequest {
-	return &DefaultRequest{}
+func NewDefaultRequest(opts ...Option) *DefaultRequest {
+	srv := &DefaultRequest{
+		maxResponseSize: DefaultMaxResponseSize,
+	}
+	for _, opt := range opts {
+		opt(srv)
+	}
+	return srv
 }
 
 // Get HTTP get request
@@ -75,9 +92,10 @@ func (srv *DefaultRequest) Get(ctx context.Context, url string, headMap map[stri
 		_ = resp.Body.Close()
 	}()
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("http get error : uri=%v , statusCode=%v", url, resp.StatusCode)
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize+1))
+		return nil, newHTTPError(req, resp, body)
 	}
-	return io.ReadAll(resp.Body)
+	return readBody(req, resp, srv.maxResponseSize)
 }
 
 // Post HTTP post request
@@ -110,9 +128,10 @@ func (srv *DefaultRequest) Post(ctx context.Context, url string, data []byte, he
 		_ = resp.Body.Close()
 	}()
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("http post error : uri=%v , statusCode=%v", url, resp.StatusCode)
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize+1))
+		return nil, newHTTPError(req, resp, body)
 	}
-	return io.ReadAll(resp.Body)
+	return readBody(req, resp, srv.maxResponseSize)
 }
 
 // PostJSON HTTP post JSON request
@@ -150,9 +169,10 @@ func (srv *DefaultRequest) PostJSON(ctx context.Context, url string, data any, h
 		_ = resp.Body.Close()
 	}()
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("http post error : uri=%v , statusCode=%v", url, resp.StatusCode)
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize+1))
+		return nil, newHTTPError(req, resp, body)
 	}
-	return io.ReadAll(resp.Body)
+	return readBody(req, resp, srv.maxResponseSize)
 }
 
 // PostJSONWithRespContentType HTTP post JSON request with the response content type
@@ -185,9 +205,10 @@ func (srv *DefaultRequest) PostJSONWithRespContentType(ctx context.Context, url
 		_ = resp.Body.Close()
 	}()
 	if resp.StatusCode != http.StatusOK {
-		return nil, "", fmt.Errorf("http post error : uri=%v , statusCode=%v", url, resp.StatusCode)
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize+1))
+		return nil, "", newHTTPError(req, resp, body)
 	}
-	res, err := io.ReadAll(resp.Body)
+	res, err := readBody(req, resp, srv.maxResponseSize)
 	contentType := resp.Header.Get(headerContentType)
 	return res, contentType, err
 }
@@ -258,9 +279,10 @@ func (srv *DefaultRequest) PostMultipartForm(ctx context.Context, url string, fi
 		_ = response.Body.Close()
 	}()
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("http post error : uri=%v , statusCode=%v", url, response.StatusCode)
+		body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize+1))
+		return nil, newHTTPError(req, response, body)
 	}
-	return io.ReadAll(response.Body)
+	return readBody(req, response, srv.maxResponseSize)
 }
 
 // PostXML perform the HTTP/POST request with XML body
@@ -294,9 +316,10 @@ func (srv *DefaultRequest) PostXML(ctx context.Context, url string, data any) ([
 	}()
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("http code error : uri=%v , statusCode=%v", url, response.StatusCode)
+		body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize+1))
+		return nil, newHTTPError(req, response, body)
 	}
-	return io.ReadAll(response.Body)
+	return readBody(req, response, srv.maxResponseSize)
 }
 
 // PostXMLWithTLS perform the HTTP/POST request with XML body and TLS
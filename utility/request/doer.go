@@ -0,0 +1,116 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package request
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Doer is the minimal HTTP client interface, *http.Client satisfies it.
+// The context of the call is carried by req.Context().
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to allow the use of ordinary functions as Doer
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req)
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// AsDoer adapts a Request to Doer, GET requests go through Request.Get and
+// every other method goes through Request.Post with the already encoded body.
+// r is always wrapped, so an implementation embedding *DefaultRequest keeps
+// its own Post. Pass an *http.Client as the Doer to send with a raw client.
+func AsDoer(r Request) Doer {
+	return &requestDoer{request: r}
+}
+
+// requestDoer adapts Request to Doer
+type requestDoer struct {
+	request Request
+}
+
+// Do perform the request through the wrapped Request
+func (d *requestDoer) Do(req *http.Request) (*http.Response, error) {
+	var (
+		ctx     = req.Context()
+		url     = req.URL.String()
+		headMap = make(map[string]string, len(req.Header))
+		body    []byte
+		err     error
+	)
+	for key := range req.Header {
+		headMap[key] = req.Header.Get(key)
+	}
+
+	if req.Method == http.MethodGet {
+		body, err = d.request.Get(ctx, url, headMap)
+	} else {
+		var data []byte
+		if req.Body != nil {
+			if data, err = io.ReadAll(req.Body); err != nil {
+				return nil, err
+			}
+			_ = req.Body.Close()
+		}
+		body, err = d.request.Post(ctx, url, data, headMap)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        strconv.Itoa(http.StatusOK) + " " + http.StatusText(http.StatusOK),
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+var (
+	defaultClient     *http.Client
+	defaultClientOnce sync.Once
+)
+
+// DefaultClient return the shared HTTP client used when no Doer is set, its
+// transport keeps idle connections to the upstream for reuse.
+func DefaultClient() *http.Client {
+	defaultClientOnce.Do(func() {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConnsPerHost = 16
+		defaultClient = &http.Client{
+			Transport: transport,
+			Timeout:   30 * time.Second,
+		}
+	})
+	return defaultClient
+}
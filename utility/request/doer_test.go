@@ -0,0 +1,63 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// overrideRequest embeds *DefaultRequest and overrides Post
+type overrideRequest struct {
+	*DefaultRequest
+	posts int
+}
+
+func (r *overrideRequest) Post(context.Context, string, []byte, map[string]string) ([]byte, error) {
+	r.posts++
+	return []byte(`{"override":true}`), nil
+}
+
+func TestAsDoer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("x", 64)))
+	}))
+	defer ts.Close()
+
+	override := &overrideRequest{DefaultRequest: NewDefaultRequest()}
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, ts.URL, strings.NewReader(`{}`))
+	resp, err := AsDoer(override).Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if override.posts != 1 {
+		t.Errorf("overridden Post calls = %d, want 1", override.posts)
+	}
+
+	limited := AsDoer(NewDefaultRequest(WithMaxResponseSize(16)))
+	req, _ = http.NewRequestWithContext(context.Background(), http.MethodPost, ts.URL, strings.NewReader(`{}`))
+	if _, err = limited.Do(req); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("Do() error = %v, want ErrResponseTooLarge from the DefaultRequest limit", err)
+	}
+}
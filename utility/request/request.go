@@ -23,7 +23,8 @@ import (
 	"context"
 )
 
-// Request HTTP request interface
+// Request HTTP request interface, the filling client only needs a Doer,
+// use AsDoer to adapt an existing implementation.
 type Request interface {
 	Get(ctx context.Context, url string, headMap map[string]string) ([]byte, error)
 	Post(ctx context.Context, url string, data []byte, headMap map[string]string) ([]byte, error)
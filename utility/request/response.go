@@ -0,0 +1,263 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package request
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultMaxResponseSize is the response body limit used when none is set, 4 MiB
+	DefaultMaxResponseSize int64 = 4 << 20
+
+	// maxErrorBodySize is the number of body bytes kept in HTTPError
+	maxErrorBodySize = 512
+)
+
+var (
+	// ErrResponseTooLarge is returned when a response body exceeds the configured limit
+	ErrResponseTooLarge = errors.New("response body too large")
+
+	// ErrTrailingData is returned by Decode when data follows the JSON value
+	ErrTrailingData = errors.New("trailing data after JSON value")
+)
+
+// Response is the envelope of an upstream HTTP response
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	Duration   time.Duration
+	Attempts   int
+	// Value is the value the body was decoded into by Decode, Body is then nil
+	Value any
+}
+
+// HTTPError is returned when the upstream answers with a non 200 status
+type HTTPError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Header     http.Header
+	// Body is the beginning of the response body, at most 512 bytes
+	Body []byte
+}
+
+// Error implements error
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("http %s error : uri=%v , statusCode=%v", strings.ToLower(e.Method), e.URL, e.StatusCode)
+	if len(e.Body) > 0 {
+		msg += fmt.Sprintf(" , body=%q", e.Body)
+	}
+	return msg
+}
+
+// RetryAfter return the delay requested by the Retry-After header, if any
+func (e *HTTPError) RetryAfter() (time.Duration, bool) {
+	value := e.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return time.Until(at), true
+	}
+	return 0, false
+}
+
+// newHTTPError build an HTTPError, the body is truncated on a rune boundary
+func newHTTPError(req *http.Request, resp *http.Response, body []byte) *HTTPError {
+	if len(body) > maxErrorBodySize {
+		body = body[:maxErrorBodySize]
+		for i := 0; i < utf8.UTFMax && len(body) > 0; i++ {
+			if r, size := utf8.DecodeLastRune(body); r != utf8.RuneError || size != 1 {
+				break
+			}
+			body = body[:len(body)-1]
+		}
+	}
+	return &HTTPError{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}
+}
+
+// Send perform req with doer and return the response envelope. A non 200
+// status returns both the envelope and an *HTTPError. The body is read up to
+// limit bytes, DefaultMaxResponseSize is used when limit is not positive.
+func Send(doer Doer, req *http.Request, limit int64) (*Response, error) {
+	return Decode(doer, req, limit, nil)
+}
+
+// Decode perform req like Send, but a 200 response whose body is a JSON
+// object is decoded into v while it is read, without buffering the body, and
+// Response.Value is v. Other responses are buffered like Send, and so is
+// every response when v is nil.
+func Decode(doer Doer, req *http.Request, limit int64, v any) (*Response, error) {
+	if limit <= 0 {
+		limit = DefaultMaxResponseSize
+	}
+	start := time.Now()
+	resp, err := doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	out := &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Attempts:   1,
+	}
+	tooLarge := fmt.Errorf("%w : uri=%v , limit=%d", ErrResponseTooLarge, req.URL, limit)
+	if resp.ContentLength > limit {
+		out.Duration = time.Since(start)
+		return out, tooLarge
+	}
+	body := bufio.NewReader(&limitReader{r: resp.Body, n: limit, err: tooLarge})
+
+	if v != nil && resp.StatusCode == http.StatusOK && isJSONObject(resp.Header, body) {
+		err = decodeJSON(body, v)
+		out.Duration = time.Since(start)
+		if err != nil {
+			if errors.Is(err, ErrTrailingData) {
+				err = fmt.Errorf("%w : uri=%v", err, req.URL)
+			}
+			return out, err
+		}
+		out.Value = v
+		return out, nil
+	}
+
+	buf := new(bytes.Buffer)
+	if resp.ContentLength > 0 {
+		buf.Grow(int(resp.ContentLength))
+	}
+	_, err = buf.ReadFrom(body)
+	out.Duration = time.Since(start)
+	if err != nil {
+		return out, err
+	}
+	out.Body = buf.Bytes()
+	if resp.StatusCode != http.StatusOK {
+		return out, newHTTPError(req, resp, out.Body)
+	}
+	return out, nil
+}
+
+// isJSONObject reports whether the body of a response is a JSON object, by
+// its content type and its first byte, without consuming it
+func isJSONObject(header http.Header, body *bufio.Reader) bool {
+	if strings.Contains(strings.ToLower(header.Get("Content-Type")), "text/html") {
+		return false
+	}
+	for n := 1; n <= body.Size(); n++ {
+		b, err := body.Peek(n)
+		if err != nil {
+			return false
+		}
+		switch b[n-1] {
+		case ' ', '\t', '\r', '\n':
+		default:
+			return b[n-1] == '{'
+		}
+	}
+	return false
+}
+
+// decodeJSON decode the single JSON value of r into v
+func decodeJSON(r io.Reader, v any) error {
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		if err != nil && errors.Is(err, ErrResponseTooLarge) {
+			return err
+		}
+		return ErrTrailingData
+	}
+	return nil
+}
+
+// limitReader read from r, failing with err once more than n bytes are read
+type limitReader struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+// Read implements io.Reader
+func (l *limitReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, l.err
+	}
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	if l.n -= int64(n); l.n < 0 {
+		return 0, l.err
+	}
+	return n, err
+}
+
+// readBody read the response body, failing with ErrResponseTooLarge once more
+// than limit bytes are announced or read
+func readBody(req *http.Request, resp *http.Response, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		limit = DefaultMaxResponseSize
+	}
+	tooLarge := func() error {
+		return fmt.Errorf("%w : uri=%v , limit=%d", ErrResponseTooLarge, req.URL, limit)
+	}
+	if resp.ContentLength > limit {
+		return nil, tooLarge()
+	}
+
+	buf := new(bytes.Buffer)
+	if resp.ContentLength > 0 {
+		buf.Grow(int(resp.ContentLength))
+	}
+	n, err := buf.ReadFrom(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if n > limit {
+		return nil, tooLarge()
+	}
+	return buf.Bytes(), nil
+}
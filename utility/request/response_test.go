@@ -0,0 +1,91 @@
+/*
+ *  Copyright icp-filing Author(https://houseme.github.io/icp-filing/). All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ *  You can obtain one at https://github.com/houseme/icp-filing.
+ */
+
+package request
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	type value struct {
+		Code int `json:"code"`
+	}
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		limit       int64
+		v           any
+		wantErr     error
+		wantHTTPErr bool
+		wantValue   bool
+	}{
+		{name: "object", status: http.StatusOK, body: `{"code":200}`, v: &value{}, wantValue: true},
+		{name: "leading space", status: http.StatusOK, body: " \n{\"code\":200}\n", v: &value{}, wantValue: true},
+		{name: "trailing data", status: http.StatusOK, body: `{"code":200}{"code":500}`, v: &value{}, wantErr: ErrTrailingData},
+		{name: "too large", status: http.StatusOK, body: `{"code":200,"msg":"` + strings.Repeat("x", 64) + `"}`, limit: 16, v: &value{}, wantErr: ErrResponseTooLarge},
+		{name: "html", status: http.StatusOK, contentType: "text/html", body: `{"code":200}`, v: &value{}},
+		{name: "not an object", status: http.StatusOK, body: `<html></html>`, v: &value{}},
+		{name: "not ok", status: http.StatusForbidden, body: `{"code":403}`, v: &value{}, wantHTTPErr: true},
+		{name: "no value", status: http.StatusOK, body: `{"code":200}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doer := DoerFunc(func(*http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode:    tt.status,
+					Header:        http.Header{"Content-Type": []string{tt.contentType}},
+					Body:          io.NopCloser(strings.NewReader(tt.body)),
+					ContentLength: -1,
+				}, nil
+			})
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com", nil)
+			resp, err := Decode(doer, req, tt.limit, tt.v)
+			var httpErr *HTTPError
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Decode() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			case tt.wantHTTPErr:
+				if !errors.As(err, &httpErr) {
+					t.Fatalf("Decode() error = %v, want *HTTPError", err)
+				}
+			case err != nil:
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if tt.wantValue {
+				if resp.Value != tt.v || resp.Body != nil || tt.v.(*value).Code != 200 {
+					t.Errorf("Decode() = %+v, want the decoded value", resp)
+				}
+				return
+			}
+			if resp.Value != nil || string(resp.Body) != tt.body {
+				t.Errorf("Decode() = %+v, want the buffered body", resp)
+			}
+		})
+	}
+}